@@ -0,0 +1,244 @@
+package admin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+// keySamplePartitions is the number of non empty partitions whose latest record is looked up for a key
+const keySamplePartitions = 3
+
+type addPartitionsCli interface {
+	client.Lister
+	client.Creator
+	client.Configurer
+	client.OffsetReader
+	client.RecordConsumer
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+type addPartitions struct {
+	addPartitionsCli
+	client.RackLister
+	topics       string
+	count        int32
+	placement    string
+	validateOnly bool
+	yes          bool
+	userInput    userInput
+}
+
+// partitionAddition is the increase in partitions of a topic, with the replicas of the new partitions if they are
+// not left to the controller
+type partitionAddition struct {
+	topic      string
+	partitions int32
+	assignment [][]int32
+	keyed      bool
+}
+
+var AddPartitionsCmd = &cobra.Command{
+	Use:   "add-partitions",
+	Short: "Increases the number of partitions of the topics",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		baseCmd := base.Init(cobraUtil)
+		a := addPartitions{
+			addPartitionsCli: baseCmd.GetTopic(),
+			RackLister:       baseCmd.GetBroker(),
+			topics:           cobraUtil.GetStringArg("topics"),
+			count:            int32(cobraUtil.GetIntArg("count")),
+			placement:        cobraUtil.GetStringArg("placement"),
+			validateOnly:     cobraUtil.GetBoolArg("validate-only"),
+			yes:              cobraUtil.GetBoolArg("yes"),
+			userInput:        &ui.UserInput{},
+		}
+		a.addPartitions()
+	},
+}
+
+func init() {
+	AddPartitionsCmd.PersistentFlags().StringP("topics", "t", "",
+		"Regex to match the topics to add partitions to. eg: \".*\", \"test-.*-topic\", \"topic1|topic2\"")
+	AddPartitionsCmd.PersistentFlags().Int32P("count", "c", 0, "Total number of partitions of the topics after the addition")
+	AddPartitionsCmd.PersistentFlags().String("placement", placementNone,
+		"Placement of the replicas of the new partitions: none, to leave it to the controller, balanced or rack-aware")
+	AddPartitionsCmd.PersistentFlags().Bool("validate-only", false, "Validate the addition of partitions without adding them")
+	AddPartitionsCmd.PersistentFlags().BoolP("yes", "y", false, "Add the partitions without asking for confirmation")
+	if err := AddPartitionsCmd.MarkPersistentFlagRequired("topics"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := AddPartitionsCmd.MarkPersistentFlagRequired("count"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (a *addPartitions) addPartitions() {
+	if a.count <= 0 {
+		logger.Fatal("count should be a positive number of partitions")
+	}
+	if a.placement != placementNone && a.placement != placementBalanced && a.placement != placementRackAware {
+		logger.Fatalf("Invalid placement %v, should be one of %v, %v or %v\n", a.placement, placementNone, placementBalanced, placementRackAware)
+	}
+
+	additions, err := a.partitionAdditions()
+	if err != nil {
+		logger.Fatalf("Error while computing the partitions to be added - %v\n", err)
+	}
+	if len(additions) == 0 {
+		logger.Info("No topics to add partitions to")
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	for _, addition := range additions {
+		tw.AddRow(ui.PartitionAddition(addition.topic, addition.partitions, a.count, addition.assignment, addition.keyed))
+		if addition.keyed {
+			logger.Warnf("Topic %v looks to be keyed, adding partitions changes the partition of the keys and breaks their ordering\n", addition.topic)
+		}
+	}
+	tw.Render()
+
+	if err := a.createPartitions(additions, true); err != nil {
+		logger.Fatalf("Error while validating the addition of partitions - %v\n", err)
+	}
+	if a.validateOnly {
+		logger.Infof("Validated the addition of partitions to %d topics\n", len(additions))
+		return
+	}
+	if !a.yes && !a.userInput.AskForConfirmation("Do you really want to add the above partitions?") {
+		return
+	}
+	if err := a.createPartitions(additions, false); err != nil {
+		logger.Fatalf("Error while adding partitions - %v\n", err)
+	}
+	logger.Infof("Added partitions to %d topics\n", len(additions))
+}
+
+// partitionAdditions returns the topics matching the regex which have fewer partitions than the count, sorted by name
+func (a *addPartitions) partitionAdditions() ([]partitionAddition, error) {
+	topics, err := a.ListOnly(a.topics, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(topics)
+	topicDetails, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var racks map[int32]string
+	if a.placement != placementNone {
+		racks = a.ListBrokerRacks()
+	}
+
+	var candidates []string
+	for _, topic := range topics {
+		detail, ok := topicDetails[topic]
+		if !ok {
+			return nil, fmt.Errorf("topic %v not found in the cluster", topic)
+		}
+		if detail.NumPartitions >= a.count {
+			logger.Warnf("Topic %v already has %d partitions, skipping\n", topic, detail.NumPartitions)
+			continue
+		}
+		candidates = append(candidates, topic)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	topicConfigs, err := a.GetConfigs(candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var additions []partitionAddition
+	for _, topic := range candidates {
+		detail := topicDetails[topic]
+		addition := partitionAddition{topic: topic, partitions: detail.NumPartitions}
+		if a.placement != placementNone {
+			addition.assignment, err = assignPartitions(detail.ReplicaAssignment, int(detail.ReplicationFactor), a.count, racks,
+				a.placement == placementRackAware)
+			if err != nil {
+				return nil, fmt.Errorf("err while assigning partitions of %v - %v", topic, err)
+			}
+		}
+		addition.keyed, err = a.isKeyed(topic, detail.NumPartitions, topicConfigs[topic])
+		if err != nil {
+			return nil, err
+		}
+		additions = append(additions, addition)
+	}
+	return additions, nil
+}
+
+// isKeyed reports whether the topic is compacted, or the latest record of any of the sampled partitions has a key.
+// The latest records of the sampled partitions are consumed together, up to the end offsets of the partitions.
+func (a *addPartitions) isKeyed(topic string, numPartitions int32, configs []client.ConfigEntry) (bool, error) {
+	for _, entry := range configs {
+		if entry.Name == "cleanup.policy" && strings.Contains(entry.Value, "compact") {
+			return true, nil
+		}
+	}
+
+	startOffsets := make(map[int32]int64)
+	endOffsets := make(map[int32]int64)
+	for partition := int32(0); partition < numPartitions && len(startOffsets) < keySamplePartitions; partition++ {
+		low, err := a.GetOffset(topic, partition, client.OffsetOldest)
+		if err != nil {
+			return false, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+		}
+		high, err := a.GetOffset(topic, partition, client.OffsetNewest)
+		if err != nil {
+			return false, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+		}
+		if high <= low {
+			continue
+		}
+		startOffsets[partition] = high - 1
+		endOffsets[partition] = high
+	}
+	if len(startOffsets) == 0 {
+		return false, nil
+	}
+
+	keyed := false
+	err := a.Consume(topic, startOffsets, endOffsets, nil, func(record client.Record) bool {
+		keyed = len(record.Key) != 0
+		return !keyed
+	})
+	if err != nil {
+		// the latest offset can be a transaction marker, which is not returned as a record
+		logger.Debugf("Err while fetching the latest records of %v - %v\n", topic, err)
+	}
+	return keyed, nil
+}
+
+// createPartitions adds the partitions to every topic, returning the errors of the topics that failed
+func (a *addPartitions) createPartitions(additions []partitionAddition, validateOnly bool) error {
+	var errs []string
+	for _, addition := range additions {
+		if err := a.CreatePartitions(addition.topic, a.count, addition.assignment, validateOnly); err != nil {
+			logger.Errorf("Err while adding partitions to topic %v - %v\n", addition.topic, err)
+			errs = append(errs, fmt.Sprintf("%v - %v", addition.topic, err))
+			continue
+		}
+		if !validateOnly {
+			logger.Infof("Added partitions to topic %v\n", addition.topic)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("err while adding partitions: %v", strings.Join(errs, ", "))
+	}
+	return nil
+}
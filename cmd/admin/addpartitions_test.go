@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddPartitionsCli struct {
+	*client.MockLister
+	*client.MockCreator
+	*client.MockConfigurer
+	*client.MockOffsetReader
+	*client.MockRecordConsumer
+}
+
+func newMockAddPartitionsCli() mockAddPartitionsCli {
+	cli := mockAddPartitionsCli{&client.MockLister{}, &client.MockCreator{}, &client.MockConfigurer{}, &client.MockOffsetReader{},
+		&client.MockRecordConsumer{}}
+	cli.MockLister.On("ListOnly", "topic-.*", true).Return([]string{"topic-2", "topic-1"}, nil)
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic-1": {NumPartitions: 1, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}}},
+		"topic-2": {NumPartitions: 4, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}, 1: {2}, 2: {3}, 3: {1}}},
+	}, nil)
+	cli.MockConfigurer.On("GetConfigs", []string{"topic-1"}).Return(map[string][]client.ConfigEntry{
+		"topic-1": {{Name: "cleanup.policy", Value: "delete"}},
+	}, nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), client.OffsetOldest).Return(int64(0), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), client.OffsetNewest).Return(int64(10), nil)
+	return cli
+}
+
+func TestAddPartitions_LeavesAssignmentToController(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{}))
+	cli.MockCreator.On("CreatePartitions", "topic-1", int32(3), [][]int32(nil), true).Return(nil).Once()
+	cli.MockCreator.On("CreatePartitions", "topic-1", int32(3), [][]int32(nil), false).Return(nil).Once()
+	a := addPartitions{addPartitionsCli: cli, topics: "topic-.*", count: 3, placement: placementNone, yes: true}
+
+	a.addPartitions()
+
+	cli.MockCreator.AssertExpectations(t)
+}
+
+func TestAddPartitions_Balanced(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{}))
+	racks := &client.MockRackLister{}
+	racks.On("ListBrokerRacks").Return(map[int32]string{1: "", 2: "", 3: ""})
+	a := addPartitions{addPartitionsCli: cli, RackLister: racks, topics: "topic-.*", count: 3, placement: placementBalanced}
+
+	additions, err := a.partitionAdditions()
+
+	require.NoError(t, err)
+	assert.Equal(t, []partitionAddition{{topic: "topic-1", partitions: 1, assignment: [][]int32{{3, 1}, {2, 3}}}}, additions)
+}
+
+func TestAddPartitions_SamplesTheLatestRecordsOfNonEmptyPartitionsInOneConsume(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	for partition, offsets := range map[int32][2]int64{0: {5, 5}, 1: {0, 3}, 2: {2, 8}, 3: {0, 1}} {
+		cli.MockOffsetReader.On("GetOffset", "topic-2", partition, client.OffsetOldest).Return(offsets[0], nil)
+		cli.MockOffsetReader.On("GetOffset", "topic-2", partition, client.OffsetNewest).Return(offsets[1], nil)
+	}
+	cli.MockRecordConsumer.On("Consume", "topic-2", map[int32]int64{1: 2, 2: 7, 3: 0}, map[int32]int64{1: 3, 2: 8, 3: 1}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{Partition: 1}, client.Record{Partition: 2, Key: []byte("key")}, client.Record{Partition: 3})).Once()
+	a := addPartitions{addPartitionsCli: cli}
+
+	keyed, err := a.isKeyed("topic-2", 4, nil)
+
+	require.NoError(t, err)
+	assert.True(t, keyed)
+	cli.MockRecordConsumer.AssertExpectations(t)
+}
+
+func TestAddPartitions_DetectsKeyedTopics(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{Key: []byte("key")}))
+	a := addPartitions{addPartitionsCli: cli, topics: "topic-.*", count: 3, placement: placementNone}
+
+	additions, err := a.partitionAdditions()
+
+	require.NoError(t, err)
+	assert.True(t, additions[0].keyed)
+
+	keyed, err := a.isKeyed("topic-3", 1, []client.ConfigEntry{{Name: "cleanup.policy", Value: "compact,delete"}})
+
+	require.NoError(t, err)
+	assert.True(t, keyed)
+}
+
+func TestAddPartitions_ValidateOnly(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{}))
+	cli.MockCreator.On("CreatePartitions", "topic-1", int32(3), [][]int32(nil), true).Return(nil).Once()
+	a := addPartitions{addPartitionsCli: cli, topics: "topic-.*", count: 3, placement: placementNone, validateOnly: true}
+
+	a.addPartitions()
+
+	cli.MockCreator.AssertExpectations(t)
+	cli.MockCreator.AssertNotCalled(t, "CreatePartitions", "topic-1", int32(3), [][]int32(nil), false)
+}
+
+func TestAddPartitions_NotConfirmed(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{}))
+	cli.MockCreator.On("CreatePartitions", "topic-1", int32(3), [][]int32(nil), true).Return(nil).Once()
+	mockUserInput := &MockUserInput{}
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
+	a := addPartitions{addPartitionsCli: cli, topics: "topic-.*", count: 3, placement: placementNone, userInput: mockUserInput}
+
+	a.addPartitions()
+
+	mockUserInput.AssertExpectations(t)
+	cli.MockCreator.AssertNotCalled(t, "CreatePartitions", "topic-1", int32(3), [][]int32(nil), false)
+}
+
+func TestAddPartitions_ValidationFailure(t *testing.T) {
+	cli := newMockAddPartitionsCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 9}, map[int32]int64{0: 10}, mock.Anything, mock.Anything).
+		Return(nil).Run(client.ReplayRecords(client.Record{}))
+	cli.MockCreator.On("CreatePartitions", "topic-1", int32(3), [][]int32(nil), true).Return(errors.New("error")).Once()
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := addPartitions{addPartitionsCli: cli, topics: "topic-.*", count: 3, placement: placementNone, yes: true}
+
+	assert.PanicsWithValue(t, "os.Exit called", a.addPartitions, "os.Exit was not called")
+	cli.MockCreator.AssertNotCalled(t, "CreatePartitions", "topic-1", int32(3), [][]int32(nil), false)
+}
+
+func TestAddPartitions_InvalidPlacement(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := addPartitions{topics: "topic-.*", count: 3, placement: "random"}
+
+	assert.PanicsWithValue(t, "os.Exit called", a.addPartitions, "os.Exit was not called")
+}
+
+type MockUserInput struct {
+	mock.Mock
+}
+
+func (m *MockUserInput) AskForConfirmation(question string) bool {
+	args := m.Called(question)
+	return args.Bool(0)
+}
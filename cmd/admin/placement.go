@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	placementNone      = "none"
+	placementBalanced  = "balanced"
+	placementRackAware = "rack-aware"
+)
+
+// assignPartitions places the replicas of the partitions added to the topic on the brokers with the least replicas
+// of the topic, choosing the leader among the brokers leading the least partitions. With rack awareness, the
+// replicas of a partition are spread across as many racks as possible.
+func assignPartitions(current map[int32][]int32, replicationFactor int, count int32, racks map[int32]string, rackAware bool) ([][]int32, error) {
+	if replicationFactor > len(racks) {
+		return nil, fmt.Errorf("replication factor %d is more than the %d brokers", replicationFactor, len(racks))
+	}
+	if rackAware {
+		for broker, rack := range racks {
+			if rack == "" {
+				return nil, fmt.Errorf("broker %d has no rack, which is needed for rack-aware placement", broker)
+			}
+		}
+	}
+
+	var brokers []int32
+	replicas := make(map[int32]int)
+	leaders := make(map[int32]int)
+	for broker := range racks {
+		brokers = append(brokers, broker)
+	}
+	sort.Slice(brokers, func(i, j int) bool { return brokers[i] < brokers[j] })
+	for _, partitionReplicas := range current {
+		for i, broker := range partitionReplicas {
+			replicas[broker]++
+			if i == 0 {
+				leaders[broker]++
+			}
+		}
+	}
+
+	var assignment [][]int32
+	for partition := int32(len(current)); partition < count; partition++ {
+		var partitionReplicas []int32
+		usedBrokers := make(map[int32]bool)
+		usedRacks := make(map[string]bool)
+		for len(partitionReplicas) < replicationFactor {
+			isLeader := len(partitionReplicas) == 0
+			broker := pickBroker(brokers, usedBrokers, usedRacks, racks, rackAware, func(a, b int32) bool {
+				if isLeader && leaders[a] != leaders[b] {
+					return leaders[a] < leaders[b]
+				}
+				return replicas[a] < replicas[b]
+			})
+			partitionReplicas = append(partitionReplicas, broker)
+			usedBrokers[broker] = true
+			usedRacks[racks[broker]] = true
+			replicas[broker]++
+			if isLeader {
+				leaders[broker]++
+			}
+		}
+		assignment = append(assignment, partitionReplicas)
+	}
+	return assignment, nil
+}
+
+// pickBroker returns the least loaded of the unused brokers, preferring the ones on unused racks when rack aware
+func pickBroker(brokers []int32, usedBrokers map[int32]bool, usedRacks map[string]bool, racks map[int32]string, rackAware bool, less func(a, b int32) bool) int32 {
+	best := int32(-1)
+	bestOnNewRack := false
+	for _, broker := range brokers {
+		if usedBrokers[broker] {
+			continue
+		}
+		onNewRack := rackAware && !usedRacks[racks[broker]]
+		if best == -1 || (onNewRack && !bestOnNewRack) || (onNewRack == bestOnNewRack && less(broker, best)) {
+			best = broker
+			bestOnNewRack = onNewRack
+		}
+	}
+	return best
+}
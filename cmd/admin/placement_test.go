@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignPartitions_Balanced(t *testing.T) {
+	current := map[int32][]int32{0: {1, 2}, 1: {2, 1}}
+	racks := map[int32]string{1: "", 2: "", 3: ""}
+
+	assignment, err := assignPartitions(current, 2, 4, racks, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]int32{{3, 1}, {3, 2}}, assignment)
+}
+
+func TestAssignPartitions_SpreadsLeaders(t *testing.T) {
+	racks := map[int32]string{1: "", 2: "", 3: ""}
+
+	assignment, err := assignPartitions(map[int32][]int32{}, 1, 3, racks, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]int32{{1}, {2}, {3}}, assignment)
+}
+
+func TestAssignPartitions_RackAware(t *testing.T) {
+	current := map[int32][]int32{0: {1, 3}}
+	racks := map[int32]string{1: "a", 2: "a", 3: "b", 4: "b"}
+
+	assignment, err := assignPartitions(current, 2, 3, racks, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]int32{{2, 4}, {3, 1}}, assignment)
+	for _, replicas := range assignment {
+		assert.NotEqual(t, racks[replicas[0]], racks[replicas[1]])
+	}
+}
+
+func TestAssignPartitions_RackAwareNeedsRacks(t *testing.T) {
+	racks := map[int32]string{1: "a", 2: ""}
+
+	_, err := assignPartitions(map[int32][]int32{0: {1}}, 1, 2, racks, true)
+
+	assert.EqualError(t, err, "broker 2 has no rack, which is needed for rack-aware placement")
+}
+
+func TestAssignPartitions_ReplicationFactorMoreThanBrokers(t *testing.T) {
+	_, err := assignPartitions(map[int32][]int32{0: {1, 2}}, 2, 2, map[int32]string{1: ""}, false)
+
+	assert.EqualError(t, err, "replication factor 2 is more than the 1 brokers")
+}
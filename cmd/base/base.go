@@ -1,20 +1,31 @@
 package base
 
 import (
+	"fmt"
+	"os/user"
 	"strings"
 
 	"github.com/gojek/kat/pkg/client"
 	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/logger"
 	"github.com/kevinburke/ssh_config"
 	"github.com/mitchellh/go-homedir"
 )
 
+// Methods to find the last write time of the topics
+const (
+	LastWriteMethodSSH = "ssh"
+	LastWriteMethodAPI = "api"
+)
+
 type Cmd struct {
 	cobraUtil  *CobraUtil
 	enableSSH  bool
 	brokerAddr string
+	addr       []string
+	apiClient  client.KafkaAPIClient
 	topic      *model.Topic
 	partition  *model.Partition
 }
@@ -51,16 +62,27 @@ func WithAddr(brokerAddr string) Opts {
 }
 
 func (b *Cmd) setTopic() {
-	addr := strings.Split(b.cobraUtil.GetStringArg(b.brokerAddr), ",")
+	b.addr = strings.Split(b.cobraUtil.GetStringArg(b.brokerAddr), ",")
 	var opts []model.TopicOpts
 	if b.enableSSH {
-		keyFile, err := homedir.Expand(b.cobraUtil.GetStringArg("ssh-key-file-path"))
+		knownHostsFile, err := homedir.Expand(b.cobraUtil.GetStringArg("ssh-known-hosts"))
 		if err != nil {
-			logger.Fatalf("Error while resolving ssh data directory - %v\n", err)
+			logger.Fatalf("Error while resolving ssh known hosts file - %v\n", err)
 		}
-		opts = append(opts, model.WithSSHClient(ssh_config.Get("*", "User"), b.cobraUtil.GetStringArg("ssh-port"), keyFile))
+		opts = append(opts, model.WithSSHClient(client.SSHConfig{
+			User:                  sshUser(),
+			Port:                  b.cobraUtil.GetStringArg("ssh-port"),
+			KeyFile:               b.cobraUtil.GetStringArg("ssh-key-file-path"),
+			JumpHost:              b.cobraUtil.GetStringArg("ssh-jump-host"),
+			KnownHostsFile:        knownHostsFile,
+			InsecureIgnoreHostKey: b.cobraUtil.GetBoolArg("ssh-insecure-ignore-host-key"),
+			Passphrase: func(keyFile string) ([]byte, error) {
+				return ui.UserInput{}.AskForPassword(fmt.Sprintf("Enter passphrase for %v", keyFile))
+			},
+		}))
 	}
-	topic, err := model.NewTopic(client.NewSaramaClient(addr), opts...)
+	b.apiClient = client.NewSaramaClient(b.addr)
+	topic, err := model.NewTopic(b.apiClient, opts...)
 	if err != nil {
 		logger.Fatalf("Err on creating topic client - %v\n", err)
 	}
@@ -68,6 +90,18 @@ func (b *Cmd) setTopic() {
 	b.topic = topic
 }
 
+// sshUser returns the user for the hosts without one in the ssh config
+func sshUser() string {
+	if sshUser := ssh_config.Get("*", "User"); sshUser != "" {
+		return sshUser
+	}
+	currentUser, err := user.Current()
+	if err != nil {
+		logger.Fatalf("Error while resolving ssh user - %v\n", err)
+	}
+	return currentUser.Username
+}
+
 func (b *Cmd) GetTopic() *model.Topic {
 	return b.topic
 }
@@ -75,3 +109,23 @@ func (b *Cmd) GetTopic() *model.Topic {
 func (b *Cmd) GetPartition() *model.Partition {
 	return b.partition
 }
+
+func (b *Cmd) GetACL() *model.ACL {
+	return model.NewACL(b.apiClient)
+}
+
+func (b *Cmd) GetConsumerGroup() *model.ConsumerGroup {
+	return model.NewConsumerGroup(b.apiClient)
+}
+
+func (b *Cmd) GetBroker() *model.Broker {
+	return model.NewBroker(b.apiClient)
+}
+
+func (b *Cmd) GetProducer(producerConfig client.ProducerConfig) *client.SaramaProducer {
+	producer, err := client.NewSaramaProducer(b.addr, producerConfig)
+	if err != nil {
+		logger.Fatalf("Err on creating producer - %v\n", err)
+	}
+	return producer
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gojek/kat/logger"
 
@@ -51,6 +52,20 @@ func (u *CobraUtil) GetBoolArg(argName string) bool {
 
 }
 
+func (u *CobraUtil) GetDurationArg(argName string) time.Duration {
+	strVal := u.GetStringArg(argName)
+	if strVal == "" {
+		return 0
+	}
+
+	val, err := time.ParseDuration(strVal)
+	if err != nil {
+		logger.Errorf("Error while retrieving duration argument: %v\n", err)
+		os.Exit(1)
+	}
+	return val
+}
+
 func (u *CobraUtil) GetStringSliceArg(argName string) []string {
 	stringSlice, err := u.cmd.Flags().GetStringSlice(argName)
 	if err != nil {
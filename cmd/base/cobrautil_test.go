@@ -2,6 +2,7 @@ package base
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +52,18 @@ func TestCobraUtil_GetIntArgReturnsIntValue(t *testing.T) {
 	assert.Equal(t, 1, value)
 }
 
+func TestCobraUtil_GetDurationArgReturnsDurationValue(t *testing.T) {
+	testCmd.SetArgs([]string{
+		"--key1=90m",
+	})
+	testCmd.Execute()
+
+	util := NewCobraUtil(testCmd)
+	value := util.GetDurationArg("key1")
+
+	assert.Equal(t, 90*time.Minute, value)
+}
+
 func TestCobraUtil_GetIntArgReturnsZeroWhenNotPresent(t *testing.T) {
 	testCmd.Execute()
 
@@ -0,0 +1,18 @@
+package base
+
+import (
+	"os"
+	"os/signal"
+)
+
+// Interrupted returns a channel which is closed on an interrupt, to stop the commands which run until interrupted
+func Interrupted() <-chan struct{} {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	stop := make(chan struct{})
+	go func() {
+		<-signals
+		close(stop)
+	}()
+	return stop
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/gojek/kat/cmd/config"
+	"github.com/gojek/kat/cmd/disk"
+	"github.com/gojek/kat/logger"
+	"github.com/spf13/cobra"
+)
+
+var brokerCmd = &cobra.Command{
+	Use:   "broker",
+	Short: "Admin commands on brokers",
+}
+
+func init() {
+	brokerCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	if err := brokerCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+
+	brokerCmd.AddCommand(config.BrokerConfigCmd)
+	brokerCmd.AddCommand(disk.BrokerDiskCmd)
+}
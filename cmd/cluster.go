@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/gojek/kat/cmd/cluster"
+	"github.com/spf13/cobra"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Admin commands on clusters",
+}
+
+func init() {
+	clusterCmd.AddCommand(cluster.DiffCmd)
+}
@@ -0,0 +1,267 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type clusterCli interface {
+	client.Lister
+	client.Configurer
+	client.ACLAdmin
+}
+
+type topicAndACLCli struct {
+	*model.Topic
+	*model.ACL
+}
+
+type clusterDiff struct {
+	leftCli        clusterCli
+	rightCli       clusterCli
+	output         string
+	excludeConfigs []string
+	allConfigs     bool
+}
+
+type diffReport struct {
+	TopicsOnlyInLeft  []string     `json:"topicsOnlyInLeft"`
+	TopicsOnlyInRight []string     `json:"topicsOnlyInRight"`
+	Topics            []topicDiff  `json:"topics"`
+	ACLsOnlyInLeft    []client.ACL `json:"aclsOnlyInLeft"`
+	ACLsOnlyInRight   []client.ACL `json:"aclsOnlyInRight"`
+}
+
+type topicDiff struct {
+	Name              string               `json:"name"`
+	Partitions        *valueDiff           `json:"partitions,omitempty"`
+	ReplicationFactor *valueDiff           `json:"replicationFactor,omitempty"`
+	Configs           map[string]valueDiff `json:"configs,omitempty"`
+}
+
+type valueDiff struct {
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+const absent = "-"
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Reports the differences in topics, configs and acls between two clusters",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+
+		leftCmd := base.Init(cobraUtil, base.WithAddr("left"))
+		rightCmd := base.Init(cobraUtil, base.WithAddr("right"))
+		c := clusterDiff{
+			leftCli:        topicAndACLCli{leftCmd.GetTopic(), leftCmd.GetACL()},
+			rightCli:       topicAndACLCli{rightCmd.GetTopic(), rightCmd.GetACL()},
+			output:         cobraUtil.GetStringArg("output"),
+			excludeConfigs: cobraUtil.GetStringSliceArg("exclude-configs"),
+			allConfigs:     cobraUtil.GetBoolArg("all-configs"),
+		}
+		c.diff()
+	},
+}
+
+func init() {
+	DiffCmd.PersistentFlags().StringP("left", "l", "", "Comma separated list of broker ips of the left cluster")
+	DiffCmd.PersistentFlags().StringP("right", "r", "", "Comma separated list of broker ips of the right cluster")
+	DiffCmd.PersistentFlags().StringP("output", "o", "table", "Format of the report. One of table, json or markdown")
+	DiffCmd.PersistentFlags().StringSlice("exclude-configs", []string{}, "Comma separated list of topic configs to be excluded from comparison")
+	DiffCmd.PersistentFlags().Bool("all-configs", false, "Compare the defaults and broker configs applying to the topics along with the configs set on the topics")
+	if err := DiffCmd.MarkPersistentFlagRequired("left"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := DiffCmd.MarkPersistentFlagRequired("right"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (c *clusterDiff) diff() {
+	if c.output != "table" && c.output != "json" && c.output != "markdown" {
+		logger.Fatalf("Unknown output format - %v\n", c.output)
+	}
+
+	report, err := c.compare()
+	if err != nil {
+		logger.Fatalf("Error while comparing clusters - %v\n", err)
+	}
+
+	if c.output == "json" {
+		reportJSON, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			logger.Fatalf("Error while rendering the report - %v\n", err)
+		}
+		fmt.Println(string(reportJSON))
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	for _, row := range report.rows() {
+		tw.AddRow(row)
+	}
+	if c.output == "markdown" {
+		tw.RenderMarkdown()
+	} else {
+		tw.Render()
+	}
+}
+
+func (c *clusterDiff) compare() (*diffReport, error) {
+	leftTopics, err := c.leftCli.List()
+	if err != nil {
+		return nil, fmt.Errorf("left cluster - err while fetching topics - %v", err)
+	}
+	rightTopics, err := c.rightCli.List()
+	if err != nil {
+		return nil, fmt.Errorf("right cluster - err while fetching topics - %v", err)
+	}
+
+	report := &diffReport{}
+	var commonTopics []string
+	for _, topic := range sortedTopics(leftTopics) {
+		if _, ok := rightTopics[topic]; !ok {
+			report.TopicsOnlyInLeft = append(report.TopicsOnlyInLeft, topic)
+			continue
+		}
+		commonTopics = append(commonTopics, topic)
+	}
+	for _, topic := range sortedTopics(rightTopics) {
+		if _, ok := leftTopics[topic]; !ok {
+			report.TopicsOnlyInRight = append(report.TopicsOnlyInRight, topic)
+		}
+	}
+
+	leftConfigs, err := c.leftCli.GetConfigs(commonTopics)
+	if err != nil {
+		return nil, fmt.Errorf("left cluster - err while reading configs - %v", err)
+	}
+	rightConfigs, err := c.rightCli.GetConfigs(commonTopics)
+	if err != nil {
+		return nil, fmt.Errorf("right cluster - err while reading configs - %v", err)
+	}
+
+	for _, topic := range commonTopics {
+		diff := c.compareTopic(topic, leftTopics[topic], rightTopics[topic], leftConfigs[topic], rightConfigs[topic])
+		if diff != nil {
+			report.Topics = append(report.Topics, *diff)
+		}
+	}
+
+	leftACLs, err := c.leftCli.ListAllACLs()
+	if err != nil {
+		return nil, fmt.Errorf("left cluster - err while fetching acls - %v", err)
+	}
+	rightACLs, err := c.rightCli.ListAllACLs()
+	if err != nil {
+		return nil, fmt.Errorf("right cluster - err while fetching acls - %v", err)
+	}
+	report.ACLsOnlyInLeft = model.ACLDifference(leftACLs, rightACLs)
+	report.ACLsOnlyInRight = model.ACLDifference(rightACLs, leftACLs)
+
+	return report, nil
+}
+
+func (c *clusterDiff) compareTopic(topic string, left, right client.TopicDetail, leftConfigs, rightConfigs []client.ConfigEntry) *topicDiff {
+	diff := topicDiff{Name: topic}
+	if left.NumPartitions != right.NumPartitions {
+		diff.Partitions = &valueDiff{Left: fmt.Sprint(left.NumPartitions), Right: fmt.Sprint(right.NumPartitions)}
+	}
+	if left.ReplicationFactor != right.ReplicationFactor {
+		diff.ReplicationFactor = &valueDiff{Left: fmt.Sprint(left.ReplicationFactor), Right: fmt.Sprint(right.ReplicationFactor)}
+	}
+
+	leftCM := c.configMap(leftConfigs)
+	rightCM := c.configMap(rightConfigs)
+	for name, leftValue := range leftCM {
+		rightValue, ok := rightCM[name]
+		if !ok {
+			rightValue = absent
+		}
+		if leftValue != rightValue {
+			diff.addConfig(name, leftValue, rightValue)
+		}
+	}
+	for name, rightValue := range rightCM {
+		if _, ok := leftCM[name]; !ok {
+			diff.addConfig(name, absent, rightValue)
+		}
+	}
+
+	if diff.Partitions == nil && diff.ReplicationFactor == nil && len(diff.Configs) == 0 {
+		return nil
+	}
+	return &diff
+}
+
+// configMap returns the configs set on the topic itself, as the defaults and broker configs differ with the broker
+// versions and properties of the clusters, or every config when all configs are compared
+func (c *clusterDiff) configMap(configs []client.ConfigEntry) map[string]string {
+	configMap := make(map[string]string)
+	for _, config := range configs {
+		if (!c.allConfigs && !config.IsTopicOverride()) || (model.ListUtil{List: c.excludeConfigs}).Contains(config.Name) {
+			continue
+		}
+		configMap[config.Name] = config.Value
+	}
+	return configMap
+}
+
+func (t *topicDiff) addConfig(name, left, right string) {
+	if t.Configs == nil {
+		t.Configs = make(map[string]valueDiff)
+	}
+	t.Configs[name] = valueDiff{Left: left, Right: right}
+}
+
+func (r *diffReport) rows() []ui.Row {
+	var rows []ui.Row
+	for _, topic := range r.TopicsOnlyInLeft {
+		rows = append(rows, ui.ClusterDiff(topic, "topic", "present", absent))
+	}
+	for _, topic := range r.TopicsOnlyInRight {
+		rows = append(rows, ui.ClusterDiff(topic, "topic", absent, "present"))
+	}
+	for _, topic := range r.Topics {
+		if topic.Partitions != nil {
+			rows = append(rows, ui.ClusterDiff(topic.Name, "partitions", topic.Partitions.Left, topic.Partitions.Right))
+		}
+		if topic.ReplicationFactor != nil {
+			rows = append(rows, ui.ClusterDiff(topic.Name, "replication factor", topic.ReplicationFactor.Left, topic.ReplicationFactor.Right))
+		}
+		var names []string
+		for name := range topic.Configs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rows = append(rows, ui.ClusterDiff(topic.Name, "config "+name, topic.Configs[name].Left, topic.Configs[name].Right))
+		}
+	}
+	for _, acl := range r.ACLsOnlyInLeft {
+		rows = append(rows, ui.ClusterDiff(acl.Resource(), "acl "+acl.Binding(), "present", absent))
+	}
+	for _, acl := range r.ACLsOnlyInRight {
+		rows = append(rows, ui.ClusterDiff(acl.Resource(), "acl "+acl.Binding(), absent, "present"))
+	}
+	return rows
+}
+
+func sortedTopics(topics map[string]client.TopicDetail) []string {
+	var names []string
+	for topic := range topics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,207 @@
+package cluster
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockClusterCli struct {
+	*client.MockLister
+	*client.MockConfigurer
+	*client.MockACLAdmin
+}
+
+func newMockClusterCli() mockClusterCli {
+	return mockClusterCli{&client.MockLister{}, &client.MockConfigurer{}, &client.MockACLAdmin{}}
+}
+
+var acl = client.ACL{
+	ResourceType:   client.ACLResourceTopic,
+	ResourceName:   "topic1",
+	PatternType:    "Literal",
+	Principal:      "User:alice",
+	Host:           "*",
+	Operation:      "Read",
+	PermissionType: "Allow",
+}
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func TestCompare_ReportsDifferences(t *testing.T) {
+	leftCli := newMockClusterCli()
+	rightCli := newMockClusterCli()
+	leftCli.MockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic1": {NumPartitions: 2, ReplicationFactor: 3},
+		"topic2": {NumPartitions: 1, ReplicationFactor: 1},
+	}, nil)
+	rightCli.MockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic1": {NumPartitions: 4, ReplicationFactor: 3},
+		"topic3": {NumPartitions: 1, ReplicationFactor: 1},
+	}, nil)
+	leftCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "retention.ms", Value: "1000"},
+			{Name: "cleanup.policy", Value: "delete"},
+			{Name: "segment.bytes", Value: "100"},
+		},
+	}, nil)
+	rightCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "retention.ms", Value: "2000"},
+			{Name: "cleanup.policy", Value: "delete"},
+			{Name: "min.insync.replicas", Value: "2"},
+		},
+	}, nil)
+	leftCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{acl}, nil)
+	rightCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{}, nil)
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli, excludeConfigs: []string{"segment.bytes"}}
+	report, err := c.compare()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic2"}, report.TopicsOnlyInLeft)
+	assert.Equal(t, []string{"topic3"}, report.TopicsOnlyInRight)
+	assert.Equal(t, []topicDiff{{
+		Name:       "topic1",
+		Partitions: &valueDiff{Left: "2", Right: "4"},
+		Configs: map[string]valueDiff{
+			"retention.ms":        {Left: "1000", Right: "2000"},
+			"min.insync.replicas": {Left: absent, Right: "2"},
+		},
+	}}, report.Topics)
+	assert.Equal(t, []client.ACL{acl}, report.ACLsOnlyInLeft)
+	assert.Empty(t, report.ACLsOnlyInRight)
+	assert.Len(t, report.rows(), 6)
+}
+
+func TestCompare_WhenTopicsAreEqual_ReportsNothing(t *testing.T) {
+	leftCli := newMockClusterCli()
+	rightCli := newMockClusterCli()
+	topics := map[string]client.TopicDetail{"topic1": {NumPartitions: 2, ReplicationFactor: 3}}
+	configs := map[string][]client.ConfigEntry{"topic1": {{Name: "retention.ms", Value: "1000"}}}
+	leftCli.MockLister.On("List").Return(topics, nil)
+	rightCli.MockLister.On("List").Return(topics, nil)
+	leftCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(configs, nil)
+	rightCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(configs, nil)
+	leftCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{acl}, nil)
+	rightCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{acl}, nil)
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli}
+	report, err := c.compare()
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.rows())
+}
+
+func TestCompare_ComparesOnlyTopicOverridesUnlessAllConfigs(t *testing.T) {
+	leftCli := newMockClusterCli()
+	rightCli := newMockClusterCli()
+	topics := map[string]client.TopicDetail{"topic1": {NumPartitions: 2, ReplicationFactor: 3}}
+	leftCli.MockLister.On("List").Return(topics, nil)
+	rightCli.MockLister.On("List").Return(topics, nil)
+	leftCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "retention.ms", Value: "1000", Source: "Topic"},
+			{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+			{Name: "cleanup.policy", Value: "delete", Default: true, Source: "Default"},
+		},
+	}, nil)
+	rightCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "retention.ms", Value: "1000", Source: "Topic"},
+			{Name: "segment.bytes", Value: "200", Source: "StaticBroker"},
+			{Name: "cleanup.policy", Value: "compact", Default: true, Source: "Default"},
+		},
+	}, nil)
+	leftCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{}, nil)
+	rightCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{}, nil)
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli}
+	report, err := c.compare()
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Topics)
+
+	c.allConfigs = true
+	report, err = c.compare()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []topicDiff{{
+		Name: "topic1",
+		Configs: map[string]valueDiff{
+			"segment.bytes":  {Left: "100", Right: "200"},
+			"cleanup.policy": {Left: "delete", Right: "compact"},
+		},
+	}}, report.Topics)
+}
+
+func TestCompare_ReportsClusterACLsPresentOnOneSide(t *testing.T) {
+	leftCli := mockClusterCli{&client.MockLister{}, &client.MockConfigurer{}, &client.MockACLAdmin{}}
+	rightCli := mockClusterCli{&client.MockLister{}, &client.MockConfigurer{}, &client.MockACLAdmin{}}
+	clusterACL := client.ACL{ResourceType: "Cluster", ResourceName: "kafka-cluster", PatternType: "Literal", Principal: "User:admin",
+		Host: "*", Operation: "Alter", PermissionType: "Allow"}
+	leftCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	rightCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	leftCli.MockConfigurer.On("GetConfigs", []string(nil)).Return(map[string][]client.ConfigEntry{}, nil)
+	rightCli.MockConfigurer.On("GetConfigs", []string(nil)).Return(map[string][]client.ConfigEntry{}, nil)
+	leftCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{acl}, nil)
+	rightCli.MockACLAdmin.On("ListAllACLs").Return([]client.ACL{acl, clusterACL}, nil)
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli}
+	report, err := c.compare()
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.ACLsOnlyInLeft)
+	assert.Equal(t, []client.ACL{clusterACL}, report.ACLsOnlyInRight)
+	assert.Len(t, report.rows(), 1)
+}
+
+func TestCompare_WhenGetConfigsFails_ReturnsError(t *testing.T) {
+	leftCli := newMockClusterCli()
+	rightCli := newMockClusterCli()
+	topics := map[string]client.TopicDetail{"topic1": {NumPartitions: 2, ReplicationFactor: 3}}
+	leftCli.MockLister.On("List").Return(topics, nil)
+	rightCli.MockLister.On("List").Return(topics, nil)
+	leftCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, errors.New("error"))
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli}
+	_, err := c.compare()
+
+	assert.Error(t, err)
+	rightCli.MockConfigurer.AssertNotCalled(t, "GetConfigs", mock.Anything)
+}
+
+func TestDiff_WhenListFails_Exits(t *testing.T) {
+	leftCli := newMockClusterCli()
+	rightCli := newMockClusterCli()
+	leftCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
+
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	c := &clusterDiff{leftCli: leftCli, rightCli: rightCli, output: "table"}
+	assert.PanicsWithValue(t, "os.Exit called", c.diff, "os.Exit was not called")
+}
+
+func TestDiff_WhenOutputFormatIsUnknown_Exits(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	c := &clusterDiff{output: "yaml"}
+	assert.PanicsWithValue(t, "os.Exit called", c.diff, "os.Exit was not called")
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
-	"strings"
+	"fmt"
+	"sort"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,8 +16,22 @@ import (
 
 type alterConfig struct {
 	client.Configurer
-	config string
-	topics []string
+	config         string
+	configFile     string
+	appendConfig   string
+	subtractConfig string
+	topics         []string
+	yes            bool
+	userInput      userInput
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+type configAlterer interface {
+	GetConfigs(resources []string) (map[string][]client.ConfigEntry, error)
+	IncrementalUpdateConfig(resources []string, entries map[string]client.IncrementalConfigEntry, validateOnly bool) error
 }
 
 var alterConfigCmd = &cobra.Command{
@@ -22,32 +39,194 @@ var alterConfigCmd = &cobra.Command{
 	Short: "alter the config for the given topics",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		a := alterConfig{Configurer: base.Init(cobraUtil).GetTopic(), config: cobraUtil.GetStringArg("config"), topics: cobraUtil.GetTopicNames()}
+		a := alterConfig{
+			Configurer:     base.Init(cobraUtil).GetTopic(),
+			config:         cobraUtil.GetStringArg("config"),
+			configFile:     cobraUtil.GetStringArg("config-file"),
+			appendConfig:   cobraUtil.GetStringArg("append-config"),
+			subtractConfig: cobraUtil.GetStringArg("subtract-config"),
+			yes:            cobraUtil.GetBoolArg("yes"),
+			userInput:      &ui.UserInput{},
+		}
+		if cobraUtil.GetStringArg("topics") != "" {
+			a.topics = cobraUtil.GetTopicNames()
+		}
 		a.alterConfig()
 	},
 }
 
 func init() {
-	alterConfigCmd.PersistentFlags().StringP("config", "c", "", "Comma separated list of configs, eg: key1=val1,key2=val2")
-	if err := alterConfigCmd.MarkPersistentFlagRequired("config"); err != nil {
-		logger.Fatal(err)
-	}
+	alterConfigCmd.PersistentFlags().StringP("config", "c", "", "Comma separated list of configs to be set, eg: key1=val1,key2=val2")
+	alterConfigCmd.PersistentFlags().StringP("config-file", "f", "", "Path to a yaml file with the configs of each topic, or a properties file with the configs for all the topics")
+	alterConfigCmd.PersistentFlags().String("append-config", "", "Comma separated list of values to be appended to list configs, eg: key1=val1")
+	alterConfigCmd.PersistentFlags().String("subtract-config", "", "Comma separated list of values to be removed from list configs, eg: key1=val1")
+	alterConfigCmd.PersistentFlags().BoolP("yes", "y", false, "Alter the configs without asking for confirmation")
 }
 
 func (a *alterConfig) alterConfig() {
-	configMap := configMap(a.config)
-	err := a.UpdateConfig(a.topics, configMap, false)
+	topicEntries, err := a.topicEntries()
+	if err != nil {
+		logger.Fatalf("Error while parsing configs - %v\n", err)
+	}
+	alterConfigs(a.Configurer, a.topics, topicEntries, a.yes, a.userInput)
+}
+
+// alterConfigs shows the config changes of every resource, validates them and applies them once confirmed.
+// It returns false when the changes are not confirmed.
+func alterConfigs(cli configAlterer, resources []string, resourceEntries map[string]map[string]client.IncrementalConfigEntry, yes bool, userInput userInput) bool {
+	currentConfigs, err := cli.GetConfigs(resources)
+	if err != nil {
+		logger.Fatalf("Error while fetching configs - %v\n", err)
+	}
+
+	var changedResources []string
+	tw := &ui.TableWriter{}
+	for _, resource := range resources {
+		rows := configChanges(resource, currentConfigs[resource], resourceEntries[resource])
+		if len(rows) == 0 {
+			continue
+		}
+		changedResources = append(changedResources, resource)
+		for _, row := range rows {
+			tw.AddRow(row)
+		}
+	}
+	if len(changedResources) == 0 {
+		logger.Info("Configs are already up to date")
+		return true
+	}
+	tw.Render()
+
+	for _, resource := range changedResources {
+		err := cli.IncrementalUpdateConfig([]string{resource}, resourceEntries[resource], true)
+		if err != nil {
+			logger.Fatalf("Error while validating config for %v - %v\n", resourceName(resource), err)
+		}
+	}
+
+	if !yes && !userInput.AskForConfirmation("Do you want to apply the above config changes?") {
+		return false
+	}
+
+	for _, resource := range changedResources {
+		err := cli.IncrementalUpdateConfig([]string{resource}, resourceEntries[resource], false)
+		if err != nil {
+			logger.Fatalf("Error while altering config - %v\n", err)
+		}
+	}
+	return true
+}
+
+func (a *alterConfig) topicEntries() (map[string]map[string]client.IncrementalConfigEntry, error) {
+	flagEntries, err := parseEntries(a.config, a.appendConfig, a.subtractConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileConfigs map[string]map[string]string
+	if a.configFile != "" {
+		fileConfigs, err = readConfigFile(a.configFile, a.topics)
+		if err != nil {
+			return nil, err
+		}
+		if len(a.topics) == 0 {
+			for topic := range fileConfigs {
+				a.topics = append(a.topics, topic)
+			}
+			sort.Strings(a.topics)
+		}
+	}
+	if len(a.topics) == 0 {
+		return nil, fmt.Errorf("topics are required unless the configs of each topic are passed in a yaml config file")
+	}
+
+	topicEntries := make(map[string]map[string]client.IncrementalConfigEntry)
+	for _, topic := range a.topics {
+		entries := make(map[string]client.IncrementalConfigEntry)
+		addEntries(entries, fileConfigs[topic], client.ConfigSet)
+		for name, entry := range flagEntries {
+			entries[name] = entry
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("no configs passed for topic %v", topic)
+		}
+		topicEntries[topic] = entries
+	}
+	return topicEntries, nil
+}
+
+func parseEntries(setConfig, appendConfig, subtractConfig string) (map[string]client.IncrementalConfigEntry, error) {
+	setConfigs, err := parseConfigs(setConfig)
+	if err != nil {
+		return nil, err
+	}
+	appendConfigs, err := parseConfigs(appendConfig)
 	if err != nil {
-		logger.Fatalf("Error while altering config - %v\n", err)
+		return nil, err
+	}
+	subtractConfigs, err := parseConfigs(subtractConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]client.IncrementalConfigEntry)
+	addEntries(entries, setConfigs, client.ConfigSet)
+	addEntries(entries, appendConfigs, client.ConfigAppend)
+	addEntries(entries, subtractConfigs, client.ConfigSubtract)
+	return entries, nil
+}
+
+func addEntries(entries map[string]client.IncrementalConfigEntry, configs map[string]string, operation client.ConfigOperation) {
+	for name, value := range configs {
+		value := value
+		entries[name] = client.IncrementalConfigEntry{Operation: operation, Value: &value}
+	}
+}
+
+func configChanges(resource string, current []client.ConfigEntry, entries map[string]client.IncrementalConfigEntry) []ui.Row {
+	currentEntries := make(map[string]client.ConfigEntry)
+	for _, entry := range current {
+		currentEntries[entry.Name] = entry
+	}
+
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows []ui.Row
+	for _, name := range names {
+		currentEntry, ok := currentEntries[name]
+		isOverridden := ok && currentEntry.IsOverridden()
+		before := "-"
+		if ok {
+			before = currentEntry.Value
+		}
+		if ok && !isOverridden {
+			before += " (default)"
+		}
+
+		after := entries[name].Apply(currentEntry.Value)
+		if after == nil && !isOverridden {
+			continue
+		}
+		if after != nil && isOverridden && *after == currentEntry.Value {
+			continue
+		}
+
+		afterValue := "(default)"
+		if after != nil {
+			afterValue = *after
+		}
+		rows = append(rows, ui.ConfigChange(resourceName(resource), name, before, afterValue))
 	}
+	return rows
 }
 
-func configMap(configStr string) map[string]*string {
-	configMap := make(map[string]*string)
-	configs := strings.Split(configStr, ",")
-	for _, config := range configs {
-		configArr := strings.Split(config, "=")
-		configMap[configArr[0]] = &configArr[1]
+func resourceName(resource string) string {
+	if resource == model.ClusterDefault {
+		return "cluster-default"
 	}
-	return configMap
+	return resource
 }
@@ -9,37 +9,197 @@ import (
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func init() {
 	logger.SetDummyLogger()
 }
 
+func setEntry(value string) client.IncrementalConfigEntry {
+	return client.IncrementalConfigEntry{Operation: client.ConfigSet, Value: &value}
+}
+
 func TestAlter_Success(t *testing.T) {
 	mockConfigurer := &client.MockConfigurer{}
+	mockUserInput := &MockUserInput{}
 	topics := []string{"topic1", "topic2"}
-	config := "key1=val1"
-	value := "val1"
-	configMap := map[string]*string{"key1": &value}
-	mockConfigurer.On("UpdateConfig", topics, configMap, false).Return(nil).Times(1)
-	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config}
+	entries := map[string]client.IncrementalConfigEntry{
+		"cleanup.policy": setEntry("compact,delete"),
+		"retention.ms":   setEntry("1000"),
+	}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"topic1"}, entries, true).Return(nil).Once()
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"topic2"}, entries, true).Return(nil).Once()
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"topic1"}, entries, false).Return(nil).Once()
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"topic2"}, entries, false).Return(nil).Once()
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "retention.ms=1000,cleanup.policy=compact,delete", userInput: mockUserInput}
+	a.alterConfig()
+	mockConfigurer.AssertExpectations(t)
+	mockUserInput.AssertExpectations(t)
+}
+
+func TestAlter_AppendAndSubtract(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	compact := "compact"
+	replica := "0:1"
+	entries := map[string]client.IncrementalConfigEntry{
+		"cleanup.policy":                        {Operation: client.ConfigAppend, Value: &compact},
+		"leader.replication.throttled.replicas": {Operation: client.ConfigSubtract, Value: &replica},
+	}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "cleanup.policy", Value: "delete", Default: true},
+			{Name: "leader.replication.throttled.replicas", Value: "0:1,1:2"},
+		},
+	}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, true).Return(nil).Once()
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(nil).Once()
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, appendConfig: "cleanup.policy=compact", subtractConfig: "leader.replication.throttled.replicas=0:1", yes: true}
 	a.alterConfig()
 	mockConfigurer.AssertExpectations(t)
 }
 
+func TestAlter_WhenNotConfirmed_DoesNotAlter(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	mockUserInput := &MockUserInput{}
+	topics := []string{"topic1"}
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": setEntry("1000")}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, true).Return(nil).Once()
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "retention.ms=1000", userInput: mockUserInput}
+	a.alterConfig()
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", topics, entries, false)
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestAlter_WhenConfigsAreUpToDate_DoesNotAlter(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{
+		"topic1": {{Name: "retention.ms", Value: "1000"}},
+	}, nil)
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "retention.ms=1000"}
+	a.alterConfig()
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAlter_WhenValidationFails_Exits(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	mockUserInput := &MockUserInput{}
+	topics := []string{"topic1"}
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": setEntry("abc")}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, true).Return(errors.New("error")).Once()
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "retention.ms=abc", userInput: mockUserInput}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	mockUserInput.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", topics, entries, false)
+}
+
+func TestAlter_TopicEntriesDefaultToTheTopicsOfTheYamlFile(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.yaml", "topic2:\n  retention.ms: 2000\ntopic1:\n  retention.ms: 1000\n")
+	defer os.RemoveAll(dir)
+	a := alterConfig{configFile: fileName, config: "segment.ms=100"}
+
+	topicEntries, err := a.topicEntries()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic1", "topic2"}, a.topics)
+	assert.Equal(t, map[string]map[string]client.IncrementalConfigEntry{
+		"topic1": {"retention.ms": setEntry("1000"), "segment.ms": setEntry("100")},
+		"topic2": {"retention.ms": setEntry("2000"), "segment.ms": setEntry("100")},
+	}, topicEntries)
+}
+
+func TestAlter_WithoutTopics_Exits(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := alterConfig{Configurer: mockConfigurer, config: "retention.ms=1000"}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "GetConfigs", mock.Anything)
+}
+
+func TestAlter_WithMalformedConfig_Exits(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := alterConfig{Configurer: mockConfigurer, topics: []string{"topic1"}, config: "retention.ms"}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "GetConfigs", mock.Anything)
+}
+
+func TestAlter_WithoutConfigs_Exits(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := alterConfig{Configurer: mockConfigurer, topics: []string{"topic1"}}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestAlter_Failure(t *testing.T) {
 	mockConfigurer := &client.MockConfigurer{}
-	topics := []string{"topic1", "topic2"}
-	config := "key1=val1"
-	value := "val1"
-	configMap := map[string]*string{"key1": &value}
-	mockConfigurer.On("UpdateConfig", topics, configMap, false).Return(errors.New("error")).Times(1)
+	topics := []string{"topic1"}
+	entries := map[string]client.IncrementalConfigEntry{"key1": setEntry("val1")}
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, true).Return(nil).Once()
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(errors.New("error")).Once()
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config}
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "key1=val1", yes: true}
 	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
 	mockConfigurer.AssertExpectations(t)
 }
+
+func TestConfigChanges(t *testing.T) {
+	current := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000"},
+		{Name: "segment.ms", Value: "2000"},
+		{Name: "cleanup.policy", Value: "delete", Default: true},
+		{Name: "segment.bytes", Value: "100", Default: true},
+	}
+	entries := map[string]client.IncrementalConfigEntry{
+		"retention.ms":   setEntry("5000"),
+		"segment.ms":     {Operation: client.ConfigDelete},
+		"cleanup.policy": setEntry("compact"),
+		"segment.bytes":  {Operation: client.ConfigDelete},
+	}
+
+	rows := configChanges("topic1", current, entries)
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, []string{"topic1", "cleanup.policy", "delete (default)", "compact"}, rows[0].FieldValues())
+	assert.Equal(t, []string{"topic1", "retention.ms", "1000", "5000"}, rows[1].FieldValues())
+	assert.Equal(t, []string{"topic1", "segment.ms", "2000", "(default)"}, rows[2].FieldValues())
+}
+
+type MockUserInput struct {
+	mock.Mock
+}
+
+func (m *MockUserInput) AskForConfirmation(question string) bool {
+	args := m.Called(question)
+	return args.Bool(0)
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+
+	"github.com/gojek/kat/cmd/base"
+
+	"github.com/gojek/kat/logger"
+	"github.com/spf13/cobra"
+)
+
+type auditCli interface {
+	client.Lister
+	client.Configurer
+}
+
+type auditConfig struct {
+	auditCli
+	policyFile string
+	fix        bool
+	yes        bool
+	userInput  userInput
+}
+
+var auditConfigCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audits the configs, replication factor and partitions of all the topics against a policy",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		a := auditConfig{
+			auditCli:   base.Init(cobraUtil).GetTopic(),
+			policyFile: cobraUtil.GetStringArg("policy"),
+			fix:        cobraUtil.GetBoolArg("fix"),
+			yes:        cobraUtil.GetBoolArg("yes"),
+			userInput:  &ui.UserInput{},
+		}
+		a.audit()
+	},
+}
+
+func init() {
+	auditConfigCmd.PersistentFlags().StringP("policy", "p", "", "Path to the yaml file with the rules for the topics")
+	auditConfigCmd.PersistentFlags().Bool("fix", false, "Alter the configs violating the policy, where possible")
+	auditConfigCmd.PersistentFlags().BoolP("yes", "y", false, "Fix the configs without asking for confirmation")
+	if err := auditConfigCmd.MarkPersistentFlagRequired("policy"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (a *auditConfig) audit() {
+	p, err := readPolicy(a.policyFile)
+	if err != nil {
+		logger.Fatalf("Error while reading policy - %v\n", err)
+	}
+
+	violations, err := a.violations(p)
+	if err != nil {
+		logger.Fatalf("Error while auditing topics - %v\n", err)
+	}
+	if len(violations) == 0 {
+		logger.Info("All the topics comply with the policy")
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	for _, v := range violations {
+		_, fixable := v.check.fixValue()
+		tw.AddRow(ui.PolicyViolation(v.topic, v.check.String(), v.actual, fixable))
+	}
+	tw.Render()
+
+	if !a.fix {
+		logger.Fatalf("Found %d policy violations\n", len(violations))
+	}
+
+	topics, topicEntries, unfixable := fixEntries(violations)
+	if len(topics) != 0 && !alterConfigs(a, topics, topicEntries, a.yes, a.userInput) {
+		logger.Fatalf("Found %d policy violations\n", len(violations))
+	}
+	if unfixable != 0 {
+		logger.Fatalf("Found %d policy violations that can not be fixed by altering configs\n", unfixable)
+	}
+}
+
+func (a *auditConfig) violations(p *policy) ([]violation, error) {
+	topicDetails, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []string
+	for topic := range topicDetails {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	topicConfigs, err := a.GetConfigs(topics)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []violation
+	for _, topic := range topics {
+		violations = append(violations, p.evaluate(topic, topicDetails[topic], topicConfigs[topic])...)
+	}
+	return violations, nil
+}
+
+// fixEntries returns the config entries that fix the violations of every topic, along with the count of
+// violations that can not be fixed by altering configs
+func fixEntries(violations []violation) ([]string, map[string]map[string]client.IncrementalConfigEntry, int) {
+	var topics []string
+	topicEntries := make(map[string]map[string]client.IncrementalConfigEntry)
+	unfixable := 0
+	for _, v := range violations {
+		value, ok := v.check.fixValue()
+		if !ok {
+			unfixable++
+			continue
+		}
+		if topicEntries[v.topic] == nil {
+			topics = append(topics, v.topic)
+			topicEntries[v.topic] = make(map[string]client.IncrementalConfigEntry)
+		}
+		topicEntries[v.topic][v.check.key] = client.IncrementalConfigEntry{Operation: client.ConfigSet, Value: &value}
+	}
+	return topics, topicEntries, unfixable
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAuditCli struct {
+	*client.MockLister
+	*client.MockConfigurer
+}
+
+func auditFixture() (*client.MockLister, *client.MockConfigurer) {
+	mockLister := &client.MockLister{}
+	mockConfigurer := &client.MockConfigurer{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{
+		"orders":           {NumPartitions: 3, ReplicationFactor: 3},
+		"orders-changelog": {NumPartitions: 3, ReplicationFactor: 3},
+	}, nil)
+	mockConfigurer.On("GetConfigs", []string{"orders", "orders-changelog"}).Return(map[string][]client.ConfigEntry{
+		"orders": {
+			{Name: "min.insync.replicas", Value: "2"},
+			{Name: "retention.ms", Value: "604800000"},
+			{Name: "cleanup.policy", Value: "delete"},
+		},
+		"orders-changelog": {
+			{Name: "min.insync.replicas", Value: "1", Default: true},
+			{Name: "retention.ms", Value: "604800000"},
+			{Name: "cleanup.policy", Value: "delete", Default: true},
+		},
+	}, nil)
+	return mockLister, mockConfigurer
+}
+
+func TestAudit_WhenTopicsComply_DoesNotExit(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", "rules:\n  - topics: \"*\"\n    checks: [\"retention.ms <= 7d\"]")
+	defer os.RemoveAll(dir)
+	mockLister, mockConfigurer := auditFixture()
+
+	a := auditConfig{auditCli: mockAuditCli{mockLister, mockConfigurer}, policyFile: fileName}
+	a.audit()
+
+	mockLister.AssertExpectations(t)
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestAudit_WhenTopicsViolatePolicy_Exits(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", testPolicy)
+	defer os.RemoveAll(dir)
+	mockLister, mockConfigurer := auditFixture()
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	a := auditConfig{auditCli: mockAuditCli{mockLister, mockConfigurer}, policyFile: fileName}
+
+	assert.PanicsWithValue(t, "os.Exit called", a.audit, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAudit_Fix(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", testPolicy)
+	defer os.RemoveAll(dir)
+	mockLister, mockConfigurer := auditFixture()
+	entries := map[string]client.IncrementalConfigEntry{
+		"min.insync.replicas": setEntry("2"),
+		"cleanup.policy":      setEntry("compact"),
+	}
+	mockConfigurer.On("GetConfigs", []string{"orders-changelog"}).Return(map[string][]client.ConfigEntry{}, nil)
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"orders-changelog"}, entries, true).Return(nil).Once()
+	mockConfigurer.On("IncrementalUpdateConfig", []string{"orders-changelog"}, entries, false).Return(nil).Once()
+
+	a := auditConfig{auditCli: mockAuditCli{mockLister, mockConfigurer}, policyFile: fileName, fix: true, yes: true}
+	a.audit()
+
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestAudit_FixWhenViolationsCanNotBeFixed_Exits(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", "rules:\n  - topics: \"*\"\n    checks: [\"replication.factor >= 5\"]")
+	defer os.RemoveAll(dir)
+	mockLister, mockConfigurer := auditFixture()
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	a := auditConfig{auditCli: mockAuditCli{mockLister, mockConfigurer}, policyFile: fileName, fix: true, yes: true}
+
+	assert.PanicsWithValue(t, "os.Exit called", a.audit, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
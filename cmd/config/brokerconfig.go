@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type brokerConfig struct {
+	client.BrokerConfigurer
+	brokerID       int
+	all            bool
+	clusterDefault bool
+}
+
+type showBrokerConfig struct {
+	brokerConfig
+	keys          []string
+	onlyOverrides bool
+}
+
+type alterBrokerConfig struct {
+	brokerConfig
+	config         string
+	appendConfig   string
+	subtractConfig string
+	yes            bool
+	userInput      userInput
+}
+
+var BrokerConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Dynamic config of brokers",
+}
+
+var showBrokerConfigCmd = &cobra.Command{
+	Use:   "show",
+	Short: "shows the config of the given brokers",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		s := showBrokerConfig{
+			brokerConfig:  newBrokerConfig(cobraUtil),
+			keys:          cobraUtil.GetStringSliceArg("keys"),
+			onlyOverrides: cobraUtil.GetBoolArg("only-overrides"),
+		}
+		s.showConfig()
+	},
+}
+
+var alterBrokerConfigCmd = &cobra.Command{
+	Use:   "alter",
+	Short: "alter the dynamic config of the given brokers",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		a := alterBrokerConfig{
+			brokerConfig:   newBrokerConfig(cobraUtil),
+			config:         cobraUtil.GetStringArg("config"),
+			appendConfig:   cobraUtil.GetStringArg("append-config"),
+			subtractConfig: cobraUtil.GetStringArg("subtract-config"),
+			yes:            cobraUtil.GetBoolArg("yes"),
+			userInput:      &ui.UserInput{},
+		}
+		a.alterConfig()
+	},
+}
+
+func init() {
+	BrokerConfigCmd.PersistentFlags().Int("broker-id", -1, "Id of the broker")
+	BrokerConfigCmd.PersistentFlags().Bool("all", false, "Apply to each of the brokers in the cluster")
+	BrokerConfigCmd.PersistentFlags().Bool("cluster-default", false, "Apply to the dynamic config shared by all the brokers in the cluster")
+
+	showBrokerConfigCmd.PersistentFlags().StringSliceP("keys", "k", []string{}, "Comma separated list of config keys to be shown, eg: key1,key2")
+	showBrokerConfigCmd.PersistentFlags().Bool("only-overrides", false, "Show only the configs set dynamically on the brokers")
+
+	alterBrokerConfigCmd.PersistentFlags().StringP("config", "c", "", "Comma separated list of configs to be set, eg: key1=val1,key2=val2")
+	alterBrokerConfigCmd.PersistentFlags().String("append-config", "", "Comma separated list of values to be appended to list configs, eg: key1=val1")
+	alterBrokerConfigCmd.PersistentFlags().String("subtract-config", "", "Comma separated list of values to be removed from list configs, eg: key1=val1")
+	alterBrokerConfigCmd.PersistentFlags().BoolP("yes", "y", false, "Alter the configs without asking for confirmation")
+
+	BrokerConfigCmd.AddCommand(showBrokerConfigCmd)
+	BrokerConfigCmd.AddCommand(alterBrokerConfigCmd)
+}
+
+func newBrokerConfig(cobraUtil *base.CobraUtil) brokerConfig {
+	return brokerConfig{
+		BrokerConfigurer: base.Init(cobraUtil).GetBroker(),
+		brokerID:         cobraUtil.GetIntArg("broker-id"),
+		all:              cobraUtil.GetBoolArg("all"),
+		clusterDefault:   cobraUtil.GetBoolArg("cluster-default"),
+	}
+}
+
+func (b *brokerConfig) brokers() ([]string, error) {
+	selected := 0
+	for _, isSelected := range []bool{b.brokerID >= 0, b.all, b.clusterDefault} {
+		if isSelected {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return nil, fmt.Errorf("any one of broker-id, all or cluster-default should be passed")
+	}
+
+	if b.clusterDefault {
+		return []string{model.ClusterDefault}, nil
+	}
+	if !b.all {
+		return []string{strconv.Itoa(b.brokerID)}, nil
+	}
+
+	var brokers []string
+	for _, id := range b.ListBrokerIDs() {
+		brokers = append(brokers, strconv.Itoa(id))
+	}
+	return brokers, nil
+}
+
+func (s *showBrokerConfig) showConfig() {
+	brokers, err := s.brokers()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	brokerConfigs, err := s.GetConfigs(brokers)
+	if err != nil {
+		logger.Fatalf("Error while fetching configs - %v\n", err)
+	}
+
+	tw := &ui.TableWriter{}
+	for _, broker := range brokers {
+		for _, config := range filterConfigs(brokerConfigs[broker], s.keys, s.onlyOverrides, client.ConfigEntry.IsOverridden) {
+			tw.AddRow(ui.Config(resourceName(broker), config))
+		}
+	}
+	tw.Render()
+}
+
+func (a *alterBrokerConfig) alterConfig() {
+	brokers, err := a.brokers()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	entries, err := parseEntries(a.config, a.appendConfig, a.subtractConfig)
+	if err != nil {
+		logger.Fatalf("Error while parsing configs - %v\n", err)
+	}
+	if len(entries) == 0 {
+		logger.Fatal("any one of config, append-config or subtract-config should be passed")
+	}
+
+	brokerEntries := make(map[string]map[string]client.IncrementalConfigEntry)
+	for _, broker := range brokers {
+		brokerEntries[broker] = entries
+	}
+	alterConfigs(a.BrokerConfigurer, brokers, brokerEntries, a.yes, a.userInput)
+}
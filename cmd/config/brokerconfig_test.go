@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBrokerConfig_Brokers(t *testing.T) {
+	brokerCli := &client.MockBrokerConfigurer{}
+	brokerCli.On("ListBrokerIDs").Return([]int{1, 2, 3})
+
+	brokers, err := (&brokerConfig{BrokerConfigurer: brokerCli, brokerID: 2}).brokers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, brokers)
+
+	brokers, err = (&brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1, all: true}).brokers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, brokers)
+
+	brokers, err = (&brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1, clusterDefault: true}).brokers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{model.ClusterDefault}, brokers)
+
+	_, err = (&brokerConfig{BrokerConfigurer: brokerCli, brokerID: 1, all: true}).brokers()
+	assert.Error(t, err)
+
+	_, err = (&brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1}).brokers()
+	assert.Error(t, err)
+}
+
+func TestShowBrokerConfig_Success(t *testing.T) {
+	brokerCli := &client.MockBrokerConfigurer{}
+	brokerCli.On("ListBrokerIDs").Return([]int{1, 2})
+	brokerCli.On("GetConfigs", []string{"1", "2"}).Return(map[string][]client.ConfigEntry{
+		"1": {{Name: "log.cleaner.threads", Value: "2", Source: "DynamicBroker"}},
+	}, nil)
+
+	s := showBrokerConfig{brokerConfig: brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1, all: true}, onlyOverrides: true}
+	s.showConfig()
+
+	brokerCli.AssertExpectations(t)
+}
+
+func TestAlterBrokerConfig_AltersEachBroker(t *testing.T) {
+	brokerCli := &client.MockBrokerConfigurer{}
+	entries := map[string]client.IncrementalConfigEntry{"log.cleaner.threads": setEntry("2")}
+	brokerCli.On("ListBrokerIDs").Return([]int{1, 2})
+	brokerCli.On("GetConfigs", []string{"1", "2"}).Return(map[string][]client.ConfigEntry{
+		"1": {{Name: "log.cleaner.threads", Value: "1", Source: "DynamicBroker"}},
+		"2": {{Name: "log.cleaner.threads", Value: "2", Source: "DynamicBroker"}},
+	}, nil)
+	brokerCli.On("IncrementalUpdateConfig", []string{"1"}, entries, true).Return(nil).Once()
+	brokerCli.On("IncrementalUpdateConfig", []string{"1"}, entries, false).Return(nil).Once()
+
+	a := alterBrokerConfig{brokerConfig: brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1, all: true}, config: "log.cleaner.threads=2", yes: true}
+	a.alterConfig()
+
+	brokerCli.AssertExpectations(t)
+	brokerCli.AssertNotCalled(t, "IncrementalUpdateConfig", []string{"2"}, mock.Anything, mock.Anything)
+}
+
+func TestAlterBrokerConfig_WithoutBrokerSelection_Exits(t *testing.T) {
+	brokerCli := &client.MockBrokerConfigurer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	a := alterBrokerConfig{brokerConfig: brokerConfig{BrokerConfigurer: brokerCli, brokerID: -1}, config: "log.cleaner.threads=2"}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	brokerCli.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -11,10 +11,15 @@ var ConfigCmd = &cobra.Command{
 }
 
 func init() {
-	ConfigCmd.PersistentFlags().StringP("topics", "t", "", "Comma separated list of topic names for which retention needs to be changed")
-	if err := ConfigCmd.MarkPersistentFlagRequired("topics"); err != nil {
-		logger.Fatal(err)
+	for _, command := range []*cobra.Command{showConfigCmd, deleteConfigCmd} {
+		command.PersistentFlags().StringP("topics", "t", "", "Comma separated list of topic names")
+		if err := command.MarkPersistentFlagRequired("topics"); err != nil {
+			logger.Fatal(err)
+		}
 	}
+	alterConfigCmd.PersistentFlags().StringP("topics", "t", "", "Comma separated list of topic names, defaults to the topics of the yaml config file")
 	ConfigCmd.AddCommand(showConfigCmd)
 	ConfigCmd.AddCommand(alterConfigCmd)
+	ConfigCmd.AddCommand(deleteConfigCmd)
+	ConfigCmd.AddCommand(auditConfigCmd)
 }
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// parseConfigs parses configs of the form key1=val1,key2=val2. A segment without "=" is treated as a continuation
+// of the previous value, so list values like cleanup.policy=compact,delete are retained as is.
+func parseConfigs(configStr string) (map[string]string, error) {
+	configs := make(map[string]string)
+	if strings.TrimSpace(configStr) == "" {
+		return configs, nil
+	}
+
+	var lastKey string
+	for _, segment := range strings.Split(configStr, ",") {
+		if !strings.Contains(segment, "=") {
+			if lastKey == "" {
+				return nil, fmt.Errorf("invalid config %v, expected key=value", segment)
+			}
+			configs[lastKey] += "," + segment
+			continue
+		}
+		configArr := strings.SplitN(segment, "=", 2)
+		key := strings.TrimSpace(configArr[0])
+		if key == "" {
+			return nil, fmt.Errorf("invalid config %v, key is empty", segment)
+		}
+		configs[key] = configArr[1]
+		lastKey = key
+	}
+	return configs, nil
+}
+
+// readConfigFile reads the configs to be set for each of the topics. Yaml files map topic names to their configs,
+// and every topic of the file is altered when no topics are given, while the configs of a properties file are
+// applied to all the topics.
+func readConfigFile(fileName string, topics []string) (map[string]map[string]string, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("err while reading config file %v - %v", fileName, err)
+	}
+
+	ext := filepath.Ext(fileName)
+	if ext == ".yaml" || ext == ".yml" {
+		topicConfigs := make(map[string]map[string]string)
+		if err := yaml.Unmarshal(data, &topicConfigs); err != nil {
+			return nil, fmt.Errorf("err while parsing config file %v - %v", fileName, err)
+		}
+		if len(topics) == 0 {
+			return topicConfigs, nil
+		}
+		passed := make(map[string]bool)
+		for _, topic := range topics {
+			if _, ok := topicConfigs[topic]; !ok {
+				return nil, fmt.Errorf("configs for topic %v not found in config file %v", topic, fileName)
+			}
+			passed[topic] = true
+		}
+		var notPassed []string
+		for topic := range topicConfigs {
+			if !passed[topic] {
+				notPassed = append(notPassed, topic)
+			}
+		}
+		if len(notPassed) != 0 {
+			sort.Strings(notPassed)
+			return nil, fmt.Errorf("topics %v of config file %v are not among the topics passed, pass them as well or leave out the topics to alter every topic of the file", strings.Join(notPassed, ","), fileName)
+		}
+		return topicConfigs, nil
+	}
+
+	configs, err := parseProperties(data)
+	if err != nil {
+		return nil, fmt.Errorf("err while parsing config file %v - %v", fileName, err)
+	}
+	topicConfigs := make(map[string]map[string]string)
+	for _, topic := range topics {
+		topicConfigs[topic] = configs
+	}
+	return topicConfigs, nil
+}
+
+func parseProperties(data []byte) (map[string]string, error) {
+	configs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		configArr := strings.SplitN(line, "=", 2)
+		if len(configArr) != 2 || strings.TrimSpace(configArr[0]) == "" {
+			return nil, fmt.Errorf("invalid config on line %d, expected key=value", lineNum)
+		}
+		configs[strings.TrimSpace(configArr[0])] = strings.TrimSpace(configArr[1])
+	}
+	return configs, scanner.Err()
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigs(t *testing.T) {
+	configs, err := parseConfigs("retention.ms=1000,cleanup.policy=compact,delete,message.format.version=2.0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"retention.ms":           "1000",
+		"cleanup.policy":         "compact,delete",
+		"message.format.version": "2.0",
+	}, configs)
+}
+
+func TestParseConfigs_WhenMalformed_ReturnsError(t *testing.T) {
+	_, err := parseConfigs("retention.ms")
+	assert.Error(t, err)
+
+	_, err = parseConfigs("=1000")
+	assert.Error(t, err)
+}
+
+func TestReadConfigFile_Yaml(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.yaml", `
+topic1:
+  retention.ms: 1000
+  cleanup.policy: compact,delete
+topic2:
+  retention.ms: 2000
+`)
+	defer os.RemoveAll(dir)
+
+	configs, err := readConfigFile(fileName, []string{"topic1", "topic2"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"topic1": {"retention.ms": "1000", "cleanup.policy": "compact,delete"},
+		"topic2": {"retention.ms": "2000"},
+	}, configs)
+}
+
+func TestReadConfigFile_YamlWithoutTopic_ReturnsError(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.yml", "topic1:\n  retention.ms: 1000\n")
+	defer os.RemoveAll(dir)
+
+	_, err := readConfigFile(fileName, []string{"topic1", "topic2"})
+
+	assert.EqualError(t, err, "configs for topic topic2 not found in config file "+fileName)
+}
+
+func TestReadConfigFile_YamlWithTopicsNotPassed_ReturnsError(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.yaml", "topic1:\n  retention.ms: 1000\ntopic3:\n  retention.ms: 3000\ntopic2:\n  retention.ms: 2000\n")
+	defer os.RemoveAll(dir)
+
+	_, err := readConfigFile(fileName, []string{"topic1"})
+
+	assert.EqualError(t, err, "topics topic2,topic3 of config file "+fileName+" are not among the topics passed, pass them as well or leave out the topics to alter every topic of the file")
+}
+
+func TestReadConfigFile_YamlWithoutTopicsPassed_ReturnsEveryTopic(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.yaml", "topic1:\n  retention.ms: 1000\ntopic2:\n  retention.ms: 2000\n")
+	defer os.RemoveAll(dir)
+
+	configs, err := readConfigFile(fileName, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"topic1": {"retention.ms": "1000"},
+		"topic2": {"retention.ms": "2000"},
+	}, configs)
+}
+
+func TestReadConfigFile_Properties(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.properties", "# retention\nretention.ms = 1000\n\ncleanup.policy=compact,delete\n")
+	defer os.RemoveAll(dir)
+
+	configs, err := readConfigFile(fileName, []string{"topic1", "topic2"})
+
+	expected := map[string]string{"retention.ms": "1000", "cleanup.policy": "compact,delete"}
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"topic1": expected, "topic2": expected}, configs)
+}
+
+func TestReadConfigFile_MalformedProperties_ReturnsError(t *testing.T) {
+	fileName, dir := writeTempFile(t, "configs.properties", "retention.ms=1000\nsegment.ms\n")
+	defer os.RemoveAll(dir)
+
+	_, err := readConfigFile(fileName, []string{"topic1"})
+
+	assert.Error(t, err)
+}
+
+func writeTempFile(t *testing.T, name, data string) (string, string) {
+	dir, err := ioutil.TempDir("", "kat")
+	require.NoError(t, err)
+	fileName := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(data), 0644))
+	return fileName, dir
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type deleteConfig struct {
+	client.Configurer
+	keys   []string
+	topics []string
+}
+
+var deleteConfigCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "delete the config overrides for the given topics, reverting them to the defaults",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		d := deleteConfig{Configurer: base.Init(cobraUtil).GetTopic(), keys: cobraUtil.GetStringSliceArg("keys"), topics: cobraUtil.GetTopicNames()}
+		d.deleteConfig()
+	},
+}
+
+func init() {
+	deleteConfigCmd.PersistentFlags().StringSliceP("keys", "k", []string{}, "Comma separated list of config keys to be deleted, eg: key1,key2")
+	if err := deleteConfigCmd.MarkPersistentFlagRequired("keys"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (d *deleteConfig) deleteConfig() {
+	entries := make(map[string]client.IncrementalConfigEntry)
+	for _, key := range d.keys {
+		entries[key] = client.IncrementalConfigEntry{Operation: client.ConfigDelete}
+	}
+
+	err := d.IncrementalUpdateConfig(d.topics, entries, false)
+	if err != nil {
+		logger.Fatalf("Error while deleting config - %v\n", err)
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelete_Success(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1", "topic2"}
+	entries := map[string]client.IncrementalConfigEntry{
+		"retention.ms":  {Operation: client.ConfigDelete},
+		"segment.bytes": {Operation: client.ConfigDelete},
+	}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(nil).Times(1)
+	d := deleteConfig{Configurer: mockConfigurer, topics: topics, keys: []string{"retention.ms", "segment.bytes"}}
+	d.deleteConfig()
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestDelete_Failure(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": {Operation: client.ConfigDelete}}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(errors.New("error")).Times(1)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteConfig{Configurer: mockConfigurer, topics: topics, keys: []string{"retention.ms"}}
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteConfig, "os.Exit was not called")
+	mockConfigurer.AssertExpectations(t)
+}
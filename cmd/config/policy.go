@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gojek/kat/pkg/client"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	replicationFactorKey = "replication.factor"
+	partitionsKey        = "partitions"
+)
+
+var operators = []string{">=", "<=", "==", "!=", ">", "<"}
+
+var durationUnits = map[string]float64{
+	"ms": 1,
+	"s":  1000,
+	"m":  60 * 1000,
+	"h":  60 * 60 * 1000,
+	"d":  24 * 60 * 60 * 1000,
+	"w":  7 * 24 * 60 * 60 * 1000,
+}
+
+type policy struct {
+	Rules []policyRule `yaml:"rules"`
+}
+
+// policyRule applies the checks to the topics matching the glob, eg: orders, *-changelog or payments-*
+type policyRule struct {
+	Topics string   `yaml:"topics"`
+	Checks []string `yaml:"checks"`
+	checks []policyCheck
+}
+
+type policyCheck struct {
+	key      string
+	operator string
+	value    string
+}
+
+type violation struct {
+	topic  string
+	check  policyCheck
+	actual string
+}
+
+func readPolicy(fileName string) (*policy, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var p policy
+	if err := yaml.UnmarshalStrict(content, &p); err != nil {
+		return nil, fmt.Errorf("err while parsing policy %v - %v", fileName, err)
+	}
+	if len(p.Rules) == 0 {
+		return nil, fmt.Errorf("no rules found in policy %v", fileName)
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if _, err := path.Match(rule.Topics, ""); err != nil {
+			return nil, fmt.Errorf("invalid topics glob %v - %v", rule.Topics, err)
+		}
+		for _, checkStr := range rule.Checks {
+			check, err := parseCheck(checkStr)
+			if err != nil {
+				return nil, err
+			}
+			rule.checks = append(rule.checks, check)
+		}
+	}
+	return &p, nil
+}
+
+func parseCheck(checkStr string) (policyCheck, error) {
+	for _, operator := range operators {
+		parts := strings.SplitN(checkStr, operator, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		check := policyCheck{key: strings.TrimSpace(parts[0]), operator: operator, value: strings.TrimSpace(parts[1])}
+		if check.key == "" || check.value == "" {
+			break
+		}
+		if check.operator != "==" && check.operator != "!=" {
+			if _, ok := parseNumber(check.value); !ok {
+				return policyCheck{}, fmt.Errorf("value of %v should be a number or a duration", checkStr)
+			}
+		}
+		return check, nil
+	}
+	return policyCheck{}, fmt.Errorf("invalid check %v, expected <key> <operator> <value>", checkStr)
+}
+
+// evaluate returns the checks that the topic violates. Checks on replication.factor and partitions are
+// evaluated against the topic detail, the rest against the configs of the topic.
+func (p *policy) evaluate(topic string, detail client.TopicDetail, configs []client.ConfigEntry) []violation {
+	values := map[string]string{
+		replicationFactorKey: fmt.Sprint(detail.ReplicationFactor),
+		partitionsKey:        fmt.Sprint(detail.NumPartitions),
+	}
+	for _, config := range configs {
+		values[config.Name] = config.Value
+	}
+
+	var violations []violation
+	for _, rule := range p.Rules {
+		if isMatched, _ := path.Match(rule.Topics, topic); !isMatched {
+			continue
+		}
+		for _, check := range rule.checks {
+			actual, ok := values[check.key]
+			if !ok {
+				violations = append(violations, violation{topic: topic, check: check, actual: "-"})
+				continue
+			}
+			if !check.isSatisfied(actual) {
+				violations = append(violations, violation{topic: topic, check: check, actual: actual})
+			}
+		}
+	}
+	return violations
+}
+
+func (c policyCheck) String() string {
+	return fmt.Sprintf("%v %v %v", c.key, c.operator, c.value)
+}
+
+func (c policyCheck) isSatisfied(actual string) bool {
+	expected, isExpectedNumber := parseNumber(c.value)
+	actualNumber, isActualNumber := parseNumber(actual)
+	if !isExpectedNumber || !isActualNumber {
+		switch c.operator {
+		case "==":
+			return actual == c.value
+		case "!=":
+			return actual != c.value
+		}
+		return false
+	}
+
+	// -1 stands for no limit in the time and size based configs, eg: retention.ms, retention.bytes
+	if actualNumber == -1 && c.operator != "==" && c.operator != "!=" && (strings.HasSuffix(c.key, ".ms") || strings.HasSuffix(c.key, ".bytes")) {
+		actualNumber = math.Inf(1)
+	}
+
+	switch c.operator {
+	case ">=":
+		return actualNumber >= expected
+	case "<=":
+		return actualNumber <= expected
+	case "==":
+		return actualNumber == expected
+	case "!=":
+		return actualNumber != expected
+	case ">":
+		return actualNumber > expected
+	case "<":
+		return actualNumber < expected
+	}
+	return false
+}
+
+// fixValue returns the value to which the config can be altered to satisfy the check
+func (c policyCheck) fixValue() (string, bool) {
+	if c.key == replicationFactorKey || c.key == partitionsKey {
+		return "", false
+	}
+	if c.operator != "==" && c.operator != ">=" && c.operator != "<=" {
+		return "", false
+	}
+	if number, ok := parseNumber(c.value); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	}
+	return c.value, true
+}
+
+// parseNumber parses plain numbers, and durations like 500ms, 30s, 10m, 12h, 7d or 2w into milliseconds
+func parseNumber(value string) (float64, bool) {
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, true
+	}
+	for _, unit := range []string{"ms", "s", "m", "h", "d", "w"} {
+		if !strings.HasSuffix(value, unit) {
+			continue
+		}
+		number, err := strconv.ParseFloat(strings.TrimSuffix(value, unit), 64)
+		if err != nil {
+			continue
+		}
+		return number * durationUnits[unit], true
+	}
+	return 0, false
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+rules:
+  - topics: "*"
+    checks:
+      - min.insync.replicas >= 2
+      - retention.ms <= 7d
+      - replication.factor >= 3
+  - topics: "*-changelog"
+    checks:
+      - cleanup.policy == compact
+`
+
+func TestReadPolicy_Success(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", testPolicy)
+	defer os.RemoveAll(dir)
+
+	p, err := readPolicy(fileName)
+
+	require.NoError(t, err)
+	assert.Len(t, p.Rules, 2)
+	assert.Equal(t, []policyCheck{
+		{key: "min.insync.replicas", operator: ">=", value: "2"},
+		{key: "retention.ms", operator: "<=", value: "7d"},
+		{key: "replication.factor", operator: ">=", value: "3"},
+	}, p.Rules[0].checks)
+	assert.Equal(t, []policyCheck{{key: "cleanup.policy", operator: "==", value: "compact"}}, p.Rules[1].checks)
+}
+
+func TestReadPolicy_InvalidPolicies(t *testing.T) {
+	for _, policyStr := range []string{
+		"rules: []",
+		"rules:\n  - topics: \"[orders\"\n    checks: [\"cleanup.policy == compact\"]",
+		"rules:\n  - topics: \"*\"\n    checks: [\"retention.ms <= week\"]",
+		"rules:\n  - topics: \"*\"\n    checks: [\"retention.ms\"]",
+		"rules:\n  - topic: \".*\"",
+	} {
+		fileName, dir := writeTempFile(t, "policy.yaml", policyStr)
+		_, err := readPolicy(fileName)
+		os.RemoveAll(dir)
+		assert.Error(t, err, policyStr)
+	}
+}
+
+func TestPolicyCheck_IsSatisfied(t *testing.T) {
+	tests := []struct {
+		check     string
+		actual    string
+		satisfied bool
+	}{
+		{"min.insync.replicas >= 2", "2", true},
+		{"min.insync.replicas >= 2", "1", false},
+		{"retention.ms <= 7d", "604800000", true},
+		{"retention.ms <= 7d", "604800001", false},
+		{"retention.ms <= 7d", "-1", false},
+		{"retention.ms == -1", "-1", true},
+		{"segment.ms > 1h", "3600000", false},
+		{"segment.ms < 1h", "60000", true},
+		{"cleanup.policy == compact", "compact", true},
+		{"cleanup.policy == compact", "compact,delete", false},
+		{"cleanup.policy != compact", "delete", true},
+		{"compression.type >= 2", "producer", false},
+	}
+	for _, test := range tests {
+		check, err := parseCheck(test.check)
+		require.NoError(t, err)
+		assert.Equal(t, test.satisfied, check.isSatisfied(test.actual), "%v with %v", test.check, test.actual)
+	}
+}
+
+func TestPolicyCheck_FixValue(t *testing.T) {
+	tests := []struct {
+		check   string
+		value   string
+		fixable bool
+	}{
+		{"retention.ms <= 7d", "604800000", true},
+		{"min.insync.replicas >= 2", "2", true},
+		{"cleanup.policy == compact", "compact", true},
+		{"cleanup.policy != compact", "", false},
+		{"segment.ms > 1h", "", false},
+		{"replication.factor >= 3", "", false},
+		{"partitions == 10", "", false},
+	}
+	for _, test := range tests {
+		check, err := parseCheck(test.check)
+		require.NoError(t, err)
+		value, fixable := check.fixValue()
+		assert.Equal(t, test.value, value, test.check)
+		assert.Equal(t, test.fixable, fixable, test.check)
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", testPolicy)
+	defer os.RemoveAll(dir)
+	p, err := readPolicy(fileName)
+	require.NoError(t, err)
+
+	violations := p.evaluate("orders-changelog", client.TopicDetail{NumPartitions: 3, ReplicationFactor: 2}, []client.ConfigEntry{
+		{Name: "min.insync.replicas", Value: "2"},
+		{Name: "retention.ms", Value: "604800000"},
+	})
+
+	assert.Equal(t, []violation{
+		{topic: "orders-changelog", check: policyCheck{key: "replication.factor", operator: ">=", value: "3"}, actual: "2"},
+		{topic: "orders-changelog", check: policyCheck{key: "cleanup.policy", operator: "==", value: "compact"}, actual: "-"},
+	}, violations)
+}
+
+func TestPolicy_EvaluateMatchesTopicsByGlob(t *testing.T) {
+	fileName, dir := writeTempFile(t, "policy.yaml", `
+rules:
+  - topics: "orders"
+    checks:
+      - partitions >= 6
+  - topics: "*-changelog"
+    checks:
+      - cleanup.policy == compact
+`)
+	defer os.RemoveAll(dir)
+	p, err := readPolicy(fileName)
+	require.NoError(t, err)
+	detail := client.TopicDetail{NumPartitions: 3, ReplicationFactor: 3}
+
+	assert.Equal(t, []violation{{topic: "orders", check: policyCheck{key: "partitions", operator: ">=", value: "6"}, actual: "3"}},
+		p.evaluate("orders", detail, nil))
+	assert.Empty(t, p.evaluate("orders-dlq", detail, nil))
+	assert.Equal(t, []violation{{topic: "orders-changelog", check: policyCheck{key: "cleanup.policy", operator: "==", value: "compact"}, actual: "-"}},
+		p.evaluate("orders-changelog", detail, nil))
+	assert.Empty(t, p.evaluate("orders-changelog-backup", detail, nil))
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
-	"fmt"
+	"sort"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,7 +15,10 @@ import (
 
 type showConfig struct {
 	client.Configurer
-	topics []string
+	topics        []string
+	keys          []string
+	onlyOverrides bool
+	compare       bool
 }
 
 var showConfigCmd = &cobra.Command{
@@ -21,27 +26,122 @@ var showConfigCmd = &cobra.Command{
 	Short: "shows the config for the given topics",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		s := showConfig{Configurer: base.Init(cobraUtil).GetTopic(), topics: cobraUtil.GetTopicNames()}
+		s := showConfig{
+			Configurer:    base.Init(cobraUtil).GetTopic(),
+			topics:        cobraUtil.GetTopicNames(),
+			keys:          cobraUtil.GetStringSliceArg("keys"),
+			onlyOverrides: cobraUtil.GetBoolArg("only-overrides"),
+			compare:       cobraUtil.GetBoolArg("compare"),
+		}
 		s.showConfig()
 	},
 }
 
+func init() {
+	showConfigCmd.PersistentFlags().StringSliceP("keys", "k", []string{}, "Comma separated list of config keys to be shown, eg: key1,key2")
+	showConfigCmd.PersistentFlags().Bool("only-overrides", false, "Show only the configs overridden on the topics")
+	showConfigCmd.PersistentFlags().Bool("compare", false, "Show the topics side by side, listing the configs that differ unless keys are passed")
+}
+
 func (s *showConfig) showConfig() {
+	topicConfigs, err := s.GetConfigs(s.topics)
+	if err != nil {
+		logger.Fatalf("Error while fetching configs - %v\n", err)
+		return
+	}
+
+	if s.compare {
+		s.compareConfigs(topicConfigs)
+		return
+	}
+
+	tw := &ui.TableWriter{}
 	for _, topicName := range s.topics {
-		configs, err := s.GetConfig(topicName)
-		if err != nil {
-			logger.Fatalf("Error while fetching config for topic %v - %v\n", topicName, err)
-			return
-		}
+		configs := filterConfigs(topicConfigs[topicName], s.keys, s.onlyOverrides, client.ConfigEntry.IsTopicOverride)
 		if len(configs) == 0 {
 			logger.Infof("Configs not found for topic - %v\n", topicName)
 			continue
 		}
-		fmt.Println("---------------------------------------------")
-		fmt.Printf("Configuration for topic - %v\n", topicName)
-		fmt.Println("---------------------------------------------")
 		for _, config := range configs {
-			fmt.Printf("%+v\n", config)
+			tw.AddRow(ui.Config(topicName, config))
+		}
+	}
+	tw.Render()
+}
+
+func (s *showConfig) compareConfigs(topicConfigs map[string][]client.ConfigEntry) {
+	rows := s.compareRows(topicConfigs)
+	if len(rows) == 0 {
+		logger.Info("Configs are the same for all the topics")
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	for _, row := range rows {
+		tw.AddRow(row)
+	}
+	tw.Render()
+}
+
+// compareRows lists the configs selected in any of the topics along with their values in every topic
+func (s *showConfig) compareRows(topicConfigs map[string][]client.ConfigEntry) []ui.Row {
+	values := make(map[string]map[string]string)
+	selected := make(map[string]bool)
+	for _, topicName := range s.topics {
+		for _, config := range topicConfigs[topicName] {
+			if values[config.Name] == nil {
+				values[config.Name] = make(map[string]string)
+			}
+			values[config.Name][topicName] = config.Value
+		}
+		for _, config := range filterConfigs(topicConfigs[topicName], s.keys, s.onlyOverrides, client.ConfigEntry.IsTopicOverride) {
+			selected[config.Name] = true
+		}
+	}
+
+	var names []string
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows []ui.Row
+	for _, name := range names {
+		var row []string
+		isDrifted := false
+		for _, topicName := range s.topics {
+			value, ok := values[name][topicName]
+			if !ok {
+				value = "-"
+			}
+			if len(row) > 0 && row[0] != value {
+				isDrifted = true
+			}
+			row = append(row, value)
+		}
+		if len(s.keys) == 0 && !isDrifted {
+			continue
+		}
+		rows = append(rows, ui.ConfigCompare(name, s.topics, row))
+	}
+	return rows
+}
+
+// filterConfigs returns the configs with the given keys, leaving out the configs which are not overrides of the
+// resource when only the overrides are to be shown
+func filterConfigs(configs []client.ConfigEntry, keys []string, onlyOverrides bool, isOverride func(client.ConfigEntry) bool) []client.ConfigEntry {
+	var filtered []client.ConfigEntry
+	for _, config := range configs {
+		if onlyOverrides && !isOverride(config) {
+			continue
+		}
+		if len(keys) > 0 && !(model.ListUtil{List: keys}).Contains(config.Name) {
+			continue
 		}
+		filtered = append(filtered, config)
 	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	return filtered
 }
@@ -17,11 +17,25 @@ func init() {
 	logger.SetDummyLogger()
 }
 
+var topicConfigs = map[string][]client.ConfigEntry{
+	"topic1": {
+		{Name: "retention.ms", Value: "1000", Source: "Topic"},
+		{Name: "cleanup.policy", Value: "delete", Source: "Default", Default: true},
+		{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+	},
+	"topic2": {
+		{Name: "retention.ms", Value: "2000", Source: "Topic"},
+		{Name: "cleanup.policy", Value: "compact", Source: "Topic"},
+		{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+	},
+}
+
 func TestShow_Success(t *testing.T) {
 	mockConfigurer := &client.MockConfigurer{}
 	topics := []string{"topic1", "topic2"}
-	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{}, nil).Times(1)
-	mockConfigurer.On("GetConfig", "topic2").Return([]client.ConfigEntry{}, nil).Times(1)
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{
+		"topic1": {{Name: "retention.ms", Value: "1000"}},
+	}, nil).Times(1)
 	s := showConfig{Configurer: mockConfigurer, topics: topics}
 	s.showConfig()
 	mockConfigurer.AssertExpectations(t)
@@ -30,7 +44,7 @@ func TestShow_Success(t *testing.T) {
 func TestShow_Failure(t *testing.T) {
 	mockConfigurer := &client.MockConfigurer{}
 	topics := []string{"topic1", "topic2"}
-	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{}, errors.New("error")).Times(1)
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, errors.New("error")).Times(1)
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -40,3 +54,56 @@ func TestShow_Failure(t *testing.T) {
 	assert.PanicsWithValue(t, "os.Exit called", s.showConfig, "os.Exit was not called")
 	mockConfigurer.AssertExpectations(t)
 }
+
+func TestShow_FilterOnlyOverridesAndKeys(t *testing.T) {
+	assert.Equal(t, []client.ConfigEntry{{Name: "cleanup.policy", Value: "compact", Source: "Topic"}, {Name: "retention.ms", Value: "2000", Source: "Topic"}}, filterConfigs(topicConfigs["topic2"], nil, true, client.ConfigEntry.IsTopicOverride))
+	assert.Equal(t, []string{"cleanup.policy", "segment.bytes"}, names(filterConfigs(topicConfigs["topic1"], []string{"segment.bytes", "cleanup.policy"}, false, client.ConfigEntry.IsTopicOverride)))
+}
+
+func TestShow_OnlyOverridesLeavesOutDynamicBrokerConfigs(t *testing.T) {
+	configs := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: "Topic"},
+		{Name: "log.cleaner.threads", Value: "2", Source: "DynamicBroker"},
+		{Name: "log.retention.hours", Value: "72", Source: "DynamicDefaultBroker"},
+	}
+
+	assert.Equal(t, []string{"retention.ms"}, names(filterConfigs(configs, nil, true, client.ConfigEntry.IsTopicOverride)))
+	assert.Equal(t, []string{"log.cleaner.threads", "log.retention.hours", "retention.ms"}, names(filterConfigs(configs, nil, true, client.ConfigEntry.IsOverridden)))
+}
+
+func TestShow_CompareListsDriftedConfigs(t *testing.T) {
+	s := showConfig{topics: []string{"topic1", "topic2"}}
+
+	rows := s.compareRows(topicConfigs)
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"Config", "topic1", "topic2"}, rows[0].Headers())
+	assert.Equal(t, []string{"cleanup.policy", "delete", "compact"}, rows[0].FieldValues())
+	assert.Equal(t, []string{"retention.ms", "1000", "2000"}, rows[1].FieldValues())
+}
+
+func TestShow_CompareListsAllPassedKeys(t *testing.T) {
+	s := showConfig{topics: []string{"topic1", "topic2"}, keys: []string{"segment.bytes"}}
+
+	rows := s.compareRows(topicConfigs)
+
+	assert.Len(t, rows, 1)
+	assert.Equal(t, []string{"segment.bytes", "100", "100"}, rows[0].FieldValues())
+}
+
+func TestShow_CompareOnlyOverridesIncludesConfigsOverriddenInAnyTopic(t *testing.T) {
+	s := showConfig{topics: []string{"topic1", "topic2"}, onlyOverrides: true}
+
+	rows := s.compareRows(topicConfigs)
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"cleanup.policy", "delete", "compact"}, rows[0].FieldValues())
+}
+
+func names(configs []client.ConfigEntry) []string {
+	var configNames []string
+	for _, config := range configs {
+		configNames = append(configNames, config.Name)
+	}
+	return configNames
+}
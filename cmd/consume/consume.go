@@ -0,0 +1,215 @@
+package consume
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type consumeCli interface {
+	client.Lister
+	client.OffsetReader
+	client.RecordConsumer
+}
+
+type consume struct {
+	consumeCli
+	client.GroupOffsetter
+	topic         string
+	partition     int32
+	fromBeginning bool
+	offset        int64
+	fromTime      int64
+	maxMessages   int
+	follow        bool
+	template      string
+	keyDecoder    string
+	valueDecoder  string
+	group         string
+	output        io.Writer
+	stop          <-chan struct{}
+}
+
+var ConsumeCmd = &cobra.Command{
+	Use:   "consume",
+	Short: "Prints the records of the topic, without joining or committing the offsets of a consumer group",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		baseCmd := base.Init(cobraUtil)
+		c := consume{
+			consumeCli:     baseCmd.GetTopic(),
+			GroupOffsetter: baseCmd.GetConsumerGroup(),
+			topic:          cobraUtil.GetStringArg("topic"),
+			partition:      int32(cobraUtil.GetIntArg("partition")),
+			fromBeginning:  cobraUtil.GetBoolArg("from-beginning"),
+			offset:         int64(cobraUtil.GetIntArg("offset")),
+			fromTime:       int64(cobraUtil.GetIntArg("from-time")),
+			maxMessages:    cobraUtil.GetIntArg("max-messages"),
+			follow:         cobraUtil.GetBoolArg("follow"),
+			template:       cobraUtil.GetStringArg("template"),
+			keyDecoder:     cobraUtil.GetStringArg("key-decoder"),
+			valueDecoder:   cobraUtil.GetStringArg("value-decoder"),
+			group:          cobraUtil.GetStringArg("group"),
+			output:         os.Stdout,
+			stop:           base.Interrupted(),
+		}
+		c.consume()
+	},
+}
+
+func init() {
+	ConsumeCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	ConsumeCmd.PersistentFlags().StringP("topic", "t", "", "Topic to consume the records of")
+	ConsumeCmd.PersistentFlags().Int32P("partition", "p", -1, "Partition to consume, all the partitions are consumed when not passed")
+	ConsumeCmd.PersistentFlags().Bool("from-beginning", false, "Consume from the earliest offset of the partitions")
+	ConsumeCmd.PersistentFlags().Int64("offset", -1, "Consume from this offset of the partitions")
+	ConsumeCmd.PersistentFlags().Int64("from-time", 0, "Consume from the records written at or after this epoch time in seconds")
+	ConsumeCmd.PersistentFlags().IntP("max-messages", "n", 0, "Stop after consuming these many records, unbounded when 0")
+	ConsumeCmd.PersistentFlags().BoolP("follow", "f", false, "Keep consuming the records written after the latest offset, until interrupted")
+	ConsumeCmd.PersistentFlags().String("template", "{{.Value}}",
+		"Go template of the output of a record, with the fields Topic, Partition, Offset, Key, Value, Headers and Timestamp")
+	ConsumeCmd.PersistentFlags().String("key-decoder", decoderString, "Decoder of the keys: string, hex, base64 or json, to pretty print")
+	ConsumeCmd.PersistentFlags().String("value-decoder", decoderString, "Decoder of the values: string, hex, base64 or json, to pretty print")
+	ConsumeCmd.PersistentFlags().String("group", "", "Commit the offsets of the consumed records for this consumer group, nothing is committed when not passed")
+	if err := ConsumeCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := ConsumeCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (c *consume) consume() {
+	selected := 0
+	for _, isSelected := range []bool{c.fromBeginning, c.offset >= 0, c.fromTime > 0} {
+		if isSelected {
+			selected++
+		}
+	}
+	if selected > 1 {
+		logger.Fatal("only one of from-beginning, offset or from-time should be passed")
+	}
+	f, err := newFormatter(c.template, c.keyDecoder, c.valueDecoder)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	startOffsets, endOffsets, err := c.offsets()
+	if err != nil {
+		logger.Fatalf("Error while fetching offsets - %v\n", err)
+	}
+	if c.follow {
+		endOffsets = nil
+	} else if !hasRecords(startOffsets, endOffsets) {
+		logger.Info("No records to consume, pass --follow to wait for new records")
+		return
+	}
+
+	consumed := 0
+	lastOffsets := make(map[int32]int64)
+	var formatErr error
+	err = c.Consume(c.topic, startOffsets, endOffsets, c.stop, func(record client.Record) bool {
+		out, err := f.format(record)
+		if err != nil {
+			formatErr = err
+			return false
+		}
+		fmt.Fprintln(c.output, out)
+		consumed++
+		lastOffsets[record.Partition] = record.Offset
+		return c.maxMessages == 0 || consumed < c.maxMessages
+	})
+	if err == nil {
+		err = formatErr
+	}
+	if err != nil {
+		logger.Fatalf("Error while consuming records, %d records were consumed - %v\n", consumed, err)
+	}
+
+	if c.group != "" && len(lastOffsets) != 0 {
+		offsets := make(map[int32]int64)
+		for partition, offset := range lastOffsets {
+			offsets[partition] = offset + 1
+		}
+		if err := c.CommitOffsets(c.group, map[string]map[int32]int64{c.topic: offsets}); err != nil {
+			logger.Fatalf("Error while committing offsets for group %v - %v\n", c.group, err)
+		}
+		logger.Infof("Committed the offsets of the consumed records for group %v\n", c.group)
+	}
+	logger.Infof("Consumed %d records\n", consumed)
+}
+
+// offsets returns the offset to start consuming every partition from, and the latest offset of the partition
+func (c *consume) offsets() (map[int32]int64, map[int32]int64, error) {
+	topicDetails, err := c.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	detail, ok := topicDetails[c.topic]
+	if !ok {
+		return nil, nil, fmt.Errorf("topic %v not found in the cluster", c.topic)
+	}
+	partitions := make([]int32, 0, detail.NumPartitions)
+	for partition := int32(0); partition < detail.NumPartitions; partition++ {
+		if c.partition < 0 || c.partition == partition {
+			partitions = append(partitions, partition)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, nil, fmt.Errorf("partition %v not found in topic %v with %d partitions", c.partition, c.topic, detail.NumPartitions)
+	}
+
+	startOffsets := make(map[int32]int64)
+	endOffsets := make(map[int32]int64)
+	for _, partition := range partitions {
+		low, err := c.GetOffset(c.topic, partition, client.OffsetOldest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("err while fetching offset of %v-%v - %v", c.topic, partition, err)
+		}
+		high, err := c.GetOffset(c.topic, partition, client.OffsetNewest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("err while fetching offset of %v-%v - %v", c.topic, partition, err)
+		}
+
+		start := high
+		switch {
+		case c.fromBeginning:
+			start = low
+		case c.offset >= 0:
+			start = c.offset
+		case c.fromTime > 0:
+			// the offset of the first record written at or after the time, none of the records are newer when there is none
+			offset, err := c.GetOffset(c.topic, partition, c.fromTime*int64(time.Second/time.Millisecond))
+			if err != nil {
+				return nil, nil, fmt.Errorf("err while fetching offset of %v-%v - %v", c.topic, partition, err)
+			}
+			if offset >= 0 {
+				start = offset
+			}
+		}
+		if start < low {
+			start = low
+		}
+		if start > high {
+			start = high
+		}
+		startOffsets[partition] = start
+		endOffsets[partition] = high
+	}
+	return startOffsets, endOffsets, nil
+}
+
+func hasRecords(startOffsets, endOffsets map[int32]int64) bool {
+	for partition, start := range startOffsets {
+		if start < endOffsets[partition] {
+			return true
+		}
+	}
+	return false
+}
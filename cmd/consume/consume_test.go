@@ -0,0 +1,154 @@
+package consume
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockConsumeCli struct {
+	*client.MockLister
+	*client.MockOffsetReader
+	*client.MockRecordConsumer
+}
+
+func newMockConsumeCli() mockConsumeCli {
+	cli := mockConsumeCli{&client.MockLister{}, &client.MockOffsetReader{}, &client.MockRecordConsumer{}}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {NumPartitions: 2}}, nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), client.OffsetOldest).Return(int64(10), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), client.OffsetNewest).Return(int64(20), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), client.OffsetOldest).Return(int64(0), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), client.OffsetNewest).Return(int64(5), nil)
+	return cli
+}
+
+func newConsume(cli mockConsumeCli) *consume {
+	return &consume{consumeCli: cli, topic: "topic-1", partition: -1, offset: -1, template: "{{.Key}}:{{.Value}}",
+		keyDecoder: decoderString, valueDecoder: decoderString, output: &bytes.Buffer{}}
+}
+
+func TestConsume_Offsets(t *testing.T) {
+	cli := newMockConsumeCli()
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), int64(1500000000000)).Return(int64(15), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), int64(1500000000000)).Return(int64(-1), nil)
+	c := newConsume(cli)
+
+	start, end, err := c.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 20, 1: 5}, start)
+	assert.Equal(t, map[int32]int64{0: 20, 1: 5}, end)
+
+	c.fromBeginning = true
+	start, _, err = c.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 10, 1: 0}, start)
+
+	c.fromBeginning = false
+	c.offset = 3
+	start, _, err = c.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 10, 1: 3}, start)
+
+	c.offset = -1
+	c.fromTime = 1500000000
+	start, _, err = c.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 15, 1: 5}, start)
+
+	c.partition = 2
+	_, _, err = c.offsets()
+	assert.EqualError(t, err, "partition 2 not found in topic topic-1 with 2 partitions")
+}
+
+func TestConsume_PrintsRecords(t *testing.T) {
+	cli := newMockConsumeCli()
+	c := newConsume(cli)
+	c.fromBeginning = true
+	c.partition = 1
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{1: 0}, map[int32]int64{1: 5}, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(client.ReplayRecords(client.Record{Partition: 1, Offset: 0, Key: []byte("k1"), Value: []byte("v1")},
+			client.Record{Partition: 1, Offset: 1, Value: []byte("v2")}))
+
+	c.consume()
+
+	assert.Equal(t, "k1:v1\nnull:v2\n", c.output.(*bytes.Buffer).String())
+}
+
+func TestConsume_MaxMessagesAndCommit(t *testing.T) {
+	cli := newMockConsumeCli()
+	mockGroupOffsetter := &client.MockGroupOffsetter{}
+	c := newConsume(cli)
+	c.GroupOffsetter = mockGroupOffsetter
+	c.fromBeginning = true
+	c.maxMessages = 2
+	c.group = "group-1"
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 10, 1: 0}, map[int32]int64{0: 20, 1: 5}, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(client.ReplayRecords(client.Record{Partition: 0, Offset: 10}, client.Record{Partition: 1, Offset: 0}, client.Record{Partition: 0, Offset: 11}))
+	mockGroupOffsetter.On("CommitOffsets", "group-1", map[string]map[int32]int64{"topic-1": {0: 11, 1: 1}}).Return(nil)
+
+	c.consume()
+
+	assert.Equal(t, "null:null\nnull:null\n", c.output.(*bytes.Buffer).String())
+	mockGroupOffsetter.AssertExpectations(t)
+}
+
+func TestConsume_FollowHasNoEndOffsets(t *testing.T) {
+	cli := newMockConsumeCli()
+	c := newConsume(cli)
+	c.follow = true
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 20, 1: 5}, map[int32]int64(nil), mock.Anything, mock.Anything).Return(nil)
+
+	c.consume()
+
+	cli.MockRecordConsumer.AssertExpectations(t)
+}
+
+func TestConsume_NothingToConsume(t *testing.T) {
+	cli := newMockConsumeCli()
+	c := newConsume(cli)
+
+	c.consume()
+
+	cli.MockRecordConsumer.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConsume_Failure(t *testing.T) {
+	cli := newMockConsumeCli()
+	c := newConsume(cli)
+	c.fromBeginning = true
+	cli.MockRecordConsumer.On("Consume", "topic-1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", c.consume, "os.Exit was not called")
+}
+
+func TestConsume_MoreThanOneStart(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	c := newConsume(newMockConsumeCli())
+	c.fromBeginning = true
+	c.offset = 5
+
+	assert.PanicsWithValue(t, "os.Exit called", c.consume, "os.Exit was not called")
+}
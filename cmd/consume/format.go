@@ -0,0 +1,92 @@
+package consume
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Decoders of the keys and values of the records
+const (
+	decoderString = "string"
+	decoderHex    = "hex"
+	decoderBase64 = "base64"
+	decoderJSON   = "json"
+)
+
+// templateRecord is the record passed to the output template, with the key and value decoded
+type templateRecord struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       string
+	Value     string
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+type formatter struct {
+	template     *template.Template
+	keyDecoder   string
+	valueDecoder string
+}
+
+func newFormatter(text, keyDecoder, valueDecoder string) (*formatter, error) {
+	for _, decoder := range []string{keyDecoder, valueDecoder} {
+		if decoder != decoderString && decoder != decoderHex && decoder != decoderBase64 && decoder != decoderJSON {
+			return nil, fmt.Errorf("unknown decoder %v, should be one of %v, %v, %v or %v", decoder, decoderString, decoderHex,
+				decoderBase64, decoderJSON)
+		}
+	}
+	tmpl, err := template.New("record").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template %v - %v", text, err)
+	}
+	return &formatter{template: tmpl, keyDecoder: keyDecoder, valueDecoder: valueDecoder}, nil
+}
+
+func (f *formatter) format(record client.Record) (string, error) {
+	r := templateRecord{
+		Topic:     record.Topic,
+		Partition: record.Partition,
+		Offset:    record.Offset,
+		Key:       decode(record.Key, f.keyDecoder),
+		Value:     decode(record.Value, f.valueDecoder),
+		Headers:   make(map[string]string),
+		Timestamp: record.Timestamp,
+	}
+	for _, header := range record.Headers {
+		r.Headers[header.Key] = string(header.Value)
+	}
+
+	var out bytes.Buffer
+	if err := f.template.Execute(&out, r); err != nil {
+		return "", fmt.Errorf("err while formatting record at offset %v of %v-%v - %v", record.Offset, record.Topic, record.Partition, err)
+	}
+	return out.String(), nil
+}
+
+// decode returns null for a missing key or a tombstone, and the data as is when it is not valid json to pretty print
+func decode(data []byte, decoder string) string {
+	if data == nil {
+		return "null"
+	}
+	switch decoder {
+	case decoderHex:
+		return hex.EncodeToString(data)
+	case decoderBase64:
+		return base64.StdEncoding.EncodeToString(data)
+	case decoderJSON:
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err == nil {
+			return out.String()
+		}
+	}
+	return string(data)
+}
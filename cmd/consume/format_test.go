@@ -0,0 +1,42 @@
+package consume
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatter_Template(t *testing.T) {
+	f, err := newFormatter(`{{.Partition}}/{{.Offset}} {{.Timestamp.Unix}} {{.Key}}={{.Value}} {{index .Headers "h1"}}`, decoderString, decoderString)
+	require.NoError(t, err)
+
+	out, err := f.format(client.Record{Topic: "topic-1", Partition: 1, Offset: 5, Key: []byte("key"), Value: []byte("value"),
+		Headers: []client.RecordHeader{{Key: "h1", Value: []byte("v1")}}, Timestamp: time.Unix(1500000000, 0)})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1/5 1500000000 key=value v1", out)
+}
+
+func TestFormatter_Decoders(t *testing.T) {
+	assert.Equal(t, "6b6579", decode([]byte("key"), decoderHex))
+	assert.Equal(t, "a2V5", decode([]byte("key"), decoderBase64))
+	assert.Equal(t, "{\n  \"id\": 1\n}", decode([]byte(`{"id":1}`), decoderJSON))
+	assert.Equal(t, "not json", decode([]byte("not json"), decoderJSON))
+	assert.Equal(t, "null", decode(nil, decoderString))
+}
+
+func TestFormatter_Invalid(t *testing.T) {
+	_, err := newFormatter("{{.Value}}", decoderString, "avro")
+	assert.EqualError(t, err, "unknown decoder avro, should be one of string, hex, base64 or json")
+
+	_, err = newFormatter("{{.Value", decoderString, decoderString)
+	assert.Error(t, err)
+
+	f, err := newFormatter("{{.Unknown}}", decoderString, decoderString)
+	require.NoError(t, err)
+	_, err = f.format(client.Record{Topic: "topic-1"})
+	assert.Error(t, err)
+}
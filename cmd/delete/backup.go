@@ -0,0 +1,135 @@
+package delete
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// backup holds what is needed to recreate the topics after they are deleted
+type backup struct {
+	CreatedAt time.Time              `yaml:"created-at"`
+	Topics    map[string]topicBackup `yaml:"topics"`
+}
+
+type topicBackup struct {
+	Partitions        int32             `yaml:"partitions"`
+	ReplicationFactor int16             `yaml:"replication-factor"`
+	ReplicaAssignment map[int32][]int32 `yaml:"replica-assignment,omitempty"`
+	Configs           map[string]string `yaml:"configs,omitempty"`
+}
+
+type backupCli interface {
+	client.Lister
+	client.Configurer
+}
+
+func newBackup(cli backupCli, topics []string) (*backup, error) {
+	topicDetails, err := cli.List()
+	if err != nil {
+		return nil, err
+	}
+	topicConfigs, err := cli.GetConfigs(topics)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &backup{CreatedAt: time.Now().UTC(), Topics: make(map[string]topicBackup)}
+	for _, topic := range topics {
+		detail, ok := topicDetails[topic]
+		if !ok {
+			return nil, fmt.Errorf("topic %v not found in the cluster", topic)
+		}
+		configs := make(map[string]string)
+		for _, entry := range topicConfigs[topic] {
+			if !entry.IsTopicOverride() {
+				continue
+			}
+			if entry.Sensitive {
+				logger.Warnf("Sensitive config %v of topic %v can not be backed up\n", entry.Name, topic)
+				continue
+			}
+			configs[entry.Name] = entry.Value
+		}
+		b.Topics[topic] = topicBackup{
+			Partitions:        detail.NumPartitions,
+			ReplicationFactor: detail.ReplicationFactor,
+			ReplicaAssignment: detail.ReplicaAssignment,
+			Configs:           configs,
+		}
+	}
+	return b, nil
+}
+
+// write saves the backup in the dir with a timestamped name, and returns the path of the file
+func (b *backup) write(dir string) (string, error) {
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("err while creating backup dir %v - %v", dir, err)
+	}
+
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	fileName := filepath.Join(dir, fmt.Sprintf("topics-%v.yaml", b.CreatedAt.Format("20060102-150405")))
+	if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+		return "", fmt.Errorf("err while writing backup %v - %v", fileName, err)
+	}
+	return fileName, nil
+}
+
+func readBackup(fileName string) (*backup, error) {
+	fileName, err := homedir.Expand(fileName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("err while reading backup %v - %v", fileName, err)
+	}
+
+	var b backup
+	if err := yaml.UnmarshalStrict(data, &b); err != nil {
+		return nil, fmt.Errorf("err while parsing backup %v - %v", fileName, err)
+	}
+	if len(b.Topics) == 0 {
+		return nil, fmt.Errorf("no topics found in backup %v", fileName)
+	}
+	return &b, nil
+}
+
+func (b *backup) topics() []string {
+	var topics []string
+	for topic := range b.Topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// detail returns the topic detail to recreate the topic, on the same replicas unless the assignment is ignored
+func (t topicBackup) detail(ignoreAssignment bool) client.TopicDetail {
+	configs := make(map[string]*string)
+	for name := range t.Configs {
+		value := t.Configs[name]
+		configs[name] = &value
+	}
+
+	if ignoreAssignment || len(t.ReplicaAssignment) == 0 {
+		return client.TopicDetail{NumPartitions: t.Partitions, ReplicationFactor: t.ReplicationFactor, Config: configs}
+	}
+	// partitions and replication factor are derived from the assignment, and should be unset when it is passed
+	return client.TopicDetail{NumPartitions: -1, ReplicationFactor: -1, ReplicaAssignment: t.ReplicaAssignment, Config: configs}
+}
@@ -0,0 +1,71 @@
+package delete
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBackupCli struct {
+	*client.MockLister
+	*client.MockConfigurer
+}
+
+func TestBackup_WriteAndRead(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic-1"}
+	mockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic-1": {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}}},
+		"topic-2": {NumPartitions: 1, ReplicationFactor: 1},
+	}, nil)
+	mockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{
+		"topic-1": {
+			{Name: "retention.ms", Value: "1000", Source: "Topic"},
+			{Name: "sasl.jaas.config", Source: "Topic", Sensitive: true},
+			{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+			{Name: "min.insync.replicas", Value: "2", Source: "DynamicBroker"},
+			{Name: "cleanup.policy", Value: "delete", Source: "Default", Default: true},
+		},
+	}, nil)
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	b, err := newBackup(mockBackupCli{mockLister, mockConfigurer}, topics)
+	require.NoError(t, err)
+	fileName, err := b.write(dir)
+	require.NoError(t, err)
+	restored, err := readBackup(fileName)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]topicBackup{
+		"topic-1": {
+			Partitions:        2,
+			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}},
+			Configs:           map[string]string{"retention.ms": "1000"},
+		},
+	}, restored.Topics)
+}
+
+func TestBackup_WhenTopicIsNotFound(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockConfigurer := &client.MockConfigurer{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	mockConfigurer.On("GetConfigs", []string{"topic-1"}).Return(map[string][]client.ConfigEntry{}, nil)
+
+	_, err := newBackup(mockBackupCli{mockLister, mockConfigurer}, []string{"topic-1"})
+	assert.EqualError(t, err, "topic topic-1 not found in the cluster")
+}
+
+func TestTopicBackup_Detail(t *testing.T) {
+	retention := "1000"
+	backup := topicBackup{Partitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}}, Configs: map[string]string{"retention.ms": retention}}
+
+	assert.Equal(t, client.TopicDetail{NumPartitions: -1, ReplicationFactor: -1, ReplicaAssignment: backup.ReplicaAssignment,
+		Config: map[string]*string{"retention.ms": &retention}}, backup.detail(false))
+	assert.Equal(t, client.TopicDetail{NumPartitions: 2, ReplicationFactor: 2, Config: map[string]*string{"retention.ms": &retention}}, backup.detail(true))
+}
@@ -0,0 +1,153 @@
+package delete
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+const offsetsConcurrency = 10
+
+type groupOffsets struct {
+	group   string
+	offsets map[string]map[int32]int64
+	err     error
+}
+
+type activityCli interface {
+	client.Lister
+	client.OffsetReader
+	client.GroupOffsetter
+}
+
+// findBlockers returns the reasons, if any, for which each topic looks to be in use: consumer groups subscribed to
+// it, consumer groups with offsets committed on it, and records written to it within the recent write window
+func findBlockers(cli activityCli, topics []string, recentWriteWindow time.Duration) (map[string][]string, error) {
+	committedGroups, failedGroups, err := groupsWithCommittedOffsets(cli, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	var topicDetails map[string]client.TopicDetail
+	if recentWriteWindow > 0 {
+		topicDetails, err = cli.List()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	topicGroups, err := cli.ListGroupsForTopics(topics)
+	if err != nil {
+		return nil, fmt.Errorf("err while listing consumer groups - %v", err)
+	}
+
+	blockers := make(map[string][]string)
+	for _, topic := range topics {
+		subscribed := make(map[string]bool)
+		for _, group := range topicGroups[topic] {
+			subscribed[group] = true
+			blockers[topic] = append(blockers[topic], fmt.Sprintf("consumed by group %v", group))
+		}
+		for _, group := range committedGroups[topic] {
+			if failedGroups[group] {
+				blockers[topic] = append(blockers[topic], fmt.Sprintf("offsets of group %v could not be fetched", group))
+			} else if !subscribed[group] {
+				blockers[topic] = append(blockers[topic], fmt.Sprintf("offsets committed by group %v", group))
+			}
+		}
+
+		if recentWriteWindow <= 0 {
+			continue
+		}
+		isWritten, err := isWrittenSince(cli, topic, topicDetails[topic].NumPartitions, time.Now().Add(-recentWriteWindow))
+		if err != nil {
+			return nil, err
+		}
+		if isWritten {
+			blockers[topic] = append(blockers[topic], fmt.Sprintf("records written in the last %v", recentWriteWindow))
+		}
+	}
+	return blockers, nil
+}
+
+// groupsWithCommittedOffsets fetches the offsets of the groups in parallel, at most offsetsConcurrency groups at a time,
+// and returns the groups with offsets committed on each topic. A group whose offsets cannot be fetched is taken to
+// have offsets committed on every topic, so that its topics are not deleted from under it.
+func groupsWithCommittedOffsets(cli activityCli, topics []string) (map[string][]string, map[string]bool, error) {
+	isCandidate := make(map[string]bool)
+	for _, topic := range topics {
+		isCandidate[topic] = true
+	}
+
+	groups, err := cli.ListGroups(".*")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make(chan string, len(groups))
+	for _, group := range groups {
+		names <- group
+	}
+	close(names)
+
+	results := make(chan groupOffsets, len(groups))
+	var wg sync.WaitGroup
+	for i := 0; i < offsetsConcurrency && i < len(groups); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range names {
+				offsets, err := cli.GetOffsets(group)
+				results <- groupOffsets{group: group, offsets: offsets, err: err}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var groupResults []groupOffsets
+	for result := range results {
+		groupResults = append(groupResults, result)
+	}
+	sort.Slice(groupResults, func(i, j int) bool {
+		return groupResults[i].group < groupResults[j].group
+	})
+
+	committedGroups := make(map[string][]string)
+	failedGroups := make(map[string]bool)
+	for _, result := range groupResults {
+		if result.err != nil {
+			logger.Errorf("Error while fetching offsets of group %v - %v\n", result.group, result.err)
+			failedGroups[result.group] = true
+			for _, topic := range topics {
+				committedGroups[topic] = append(committedGroups[topic], result.group)
+			}
+			continue
+		}
+		for topic := range result.offsets {
+			if isCandidate[topic] {
+				committedGroups[topic] = append(committedGroups[topic], result.group)
+			}
+		}
+	}
+	return committedGroups, failedGroups, nil
+}
+
+// isWrittenSince looks up the first record with a timestamp after the given time in every partition of the topic
+func isWrittenSince(cli activityCli, topic string, numPartitions int32, since time.Time) (bool, error) {
+	timestamp := since.UnixNano() / int64(time.Millisecond)
+	for partition := int32(0); partition < numPartitions; partition++ {
+		offset, err := cli.GetOffset(topic, partition, timestamp)
+		if err != nil {
+			return false, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+		}
+		if offset >= 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
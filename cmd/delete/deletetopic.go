@@ -2,6 +2,9 @@ package delete
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/gojek/kat/pkg/client"
 
@@ -15,16 +18,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const deletionPollInterval = 2 * time.Second
+
+// defaultProtectedTopics are the internal topics of kafka and the confluent platform, which are never deleted
+var defaultProtectedTopics = []string{"^__", "^_schemas$", "^_confluent"}
+
 type deleteTopic struct {
 	client.Lister
 	client.Deleter
-	lastWrite      int64
-	dataDir        string
-	topicWhitelist string
-	topicBlacklist string
-	sshPort        string
-	sshKeyFilePath string
-	userInput      userInput
+	client.Configurer
+	client.OffsetReader
+	client.GroupOffsetter
+	lastWrite         int64
+	method            string
+	allowPartial      bool
+	dataDir           string
+	topicWhitelist    string
+	topicBlacklist    string
+	sshPort           string
+	sshKeyFilePath    string
+	protected         []string
+	backupDir         string
+	dryRun            bool
+	force             bool
+	recentWriteWindow time.Duration
+	yes               bool
+	maxTopics         int
+	batchSize         int
+	delay             time.Duration
+	batchTimeout      time.Duration
+	pollInterval      time.Duration
+	userInput         userInput
 }
 
 type userInput interface {
@@ -37,22 +61,39 @@ var DeleteTopicCmd = &cobra.Command{
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
 		lastWrite := int64(cobraUtil.GetIntArg("last-write"))
+		method := cobraUtil.GetStringArg("method")
 		var baseCmd *base.Cmd
-		if lastWrite == 0 {
+		if lastWrite == 0 || method == base.LastWriteMethodAPI {
 			baseCmd = base.Init(cobraUtil)
 		} else {
 			baseCmd = base.Init(cobraUtil, base.WithSSH())
 		}
 		d := deleteTopic{
-			Lister:         baseCmd.GetTopic(),
-			Deleter:        baseCmd.GetTopic(),
-			lastWrite:      lastWrite,
-			dataDir:        cobraUtil.GetStringArg("data-dir"),
-			topicWhitelist: cobraUtil.GetStringArg("topic-whitelist"),
-			topicBlacklist: cobraUtil.GetStringArg("topic-blacklist"),
-			sshPort:        cobraUtil.GetStringArg("ssh-port"),
-			sshKeyFilePath: cobraUtil.GetStringArg("ssh-key-file-path"),
-			userInput:      &ui.UserInput{},
+			Lister:            baseCmd.GetTopic(),
+			Deleter:           baseCmd.GetTopic(),
+			Configurer:        baseCmd.GetTopic(),
+			OffsetReader:      baseCmd.GetTopic(),
+			GroupOffsetter:    baseCmd.GetConsumerGroup(),
+			lastWrite:         lastWrite,
+			method:            method,
+			allowPartial:      cobraUtil.GetBoolArg("allow-partial"),
+			dataDir:           cobraUtil.GetStringArg("data-dir"),
+			topicWhitelist:    cobraUtil.GetStringArg("topic-whitelist"),
+			topicBlacklist:    cobraUtil.GetStringArg("topic-blacklist"),
+			sshPort:           cobraUtil.GetStringArg("ssh-port"),
+			sshKeyFilePath:    cobraUtil.GetStringArg("ssh-key-file-path"),
+			protected:         cobraUtil.GetStringSliceArg("protected-topics"),
+			backupDir:         cobraUtil.GetStringArg("backup-dir"),
+			dryRun:            cobraUtil.GetBoolArg("dry-run"),
+			force:             cobraUtil.GetBoolArg("force"),
+			recentWriteWindow: cobraUtil.GetDurationArg("recent-write-window"),
+			yes:               cobraUtil.GetBoolArg("yes"),
+			maxTopics:         cobraUtil.GetIntArg("max-topics"),
+			batchSize:         cobraUtil.GetIntArg("batch-size"),
+			delay:             cobraUtil.GetDurationArg("delay"),
+			batchTimeout:      cobraUtil.GetDurationArg("batch-timeout"),
+			pollInterval:      deletionPollInterval,
+			userInput:         &ui.UserInput{},
 		}
 		d.deleteTopic()
 	},
@@ -61,10 +102,25 @@ var DeleteTopicCmd = &cobra.Command{
 func init() {
 	DeleteTopicCmd.PersistentFlags().Int64P("last-write", "l", 0, "Last write time for topics in epoch format")
 	DeleteTopicCmd.PersistentFlags().StringP("data-dir", "d", "/var/log/kafka", "Data directory for kafka logs")
+	DeleteTopicCmd.PersistentFlags().String("method", base.LastWriteMethodSSH, "Method to find the last write time of the topics. One of ssh, which reads the data directory on the brokers, or api, which looks up the record timestamps")
 	DeleteTopicCmd.PersistentFlags().StringP("topic-whitelist", "", "", "Regex pattern to include topics")
 	DeleteTopicCmd.PersistentFlags().StringP("topic-blacklist", "", "", "Regex pattern to exclude topics")
 	DeleteTopicCmd.PersistentFlags().StringP("ssh-port", "p", ssh_config.Default("Port"), "Ssh port on the kafka brokers")
+	DeleteTopicCmd.PersistentFlags().Bool("allow-partial", false, "List the stale topics from the reachable brokers when some of the brokers can not be sshed into")
 	DeleteTopicCmd.PersistentFlags().StringP("ssh-key-file-path", "k", "~/.ssh/id_rsa", "Path to ssh key file")
+	DeleteTopicCmd.PersistentFlags().String("ssh-jump-host", "", "Jump host to ssh into the brokers through, of the form [user@]host[:port]")
+	DeleteTopicCmd.PersistentFlags().String("ssh-known-hosts", "~/.ssh/known_hosts", "Path to the known hosts file to verify the host keys of the brokers")
+	DeleteTopicCmd.PersistentFlags().Bool("ssh-insecure-ignore-host-key", false, "Skip verifying the host keys of the brokers")
+	DeleteTopicCmd.PersistentFlags().StringSlice("protected-topics", []string{}, "Comma separated list of regex patterns of topics that are never deleted, in addition to the internal topics")
+	DeleteTopicCmd.PersistentFlags().String("backup-dir", "~/.kat/backups", "Directory to back up the partitions, replication factor and configs of the topics to, before deleting them")
+	DeleteTopicCmd.PersistentFlags().Bool("dry-run", false, "List the topics that would be deleted without deleting them")
+	DeleteTopicCmd.PersistentFlags().Bool("force", false, "Delete the topics even when they have consumers, committed offsets or recent writes")
+	DeleteTopicCmd.PersistentFlags().Duration("recent-write-window", 24*time.Hour, "Topics with records written within this duration are not deleted without --force, 0 to skip the check")
+	DeleteTopicCmd.PersistentFlags().BoolP("yes", "y", false, "Delete the topics without asking for confirmation")
+	DeleteTopicCmd.PersistentFlags().Int("max-topics", 0, "Refuse to delete more than these many topics in one run, no limit when 0")
+	DeleteTopicCmd.PersistentFlags().Int("batch-size", 0, "Delete the topics in batches of this size, waiting for each batch to be removed from the metadata before the next. 0 deletes all of them at once")
+	DeleteTopicCmd.PersistentFlags().Duration("delay", 0, "Time to wait between the batches")
+	DeleteTopicCmd.PersistentFlags().Duration("batch-timeout", 5*time.Minute, "Time to wait for a batch to be removed from the metadata")
 }
 
 func (d *deleteTopic) deleteTopic() {
@@ -76,21 +132,158 @@ func (d *deleteTopic) deleteTopic() {
 	if err != nil {
 		logger.Fatal(err)
 	}
+	topics, err = d.removeProtected(topics)
+	if err != nil {
+		logger.Fatal(err)
+	}
 	if len(topics) == 0 {
 		return
 	}
-	fmt.Println("------------------------------------------------------------")
+	topics, err = d.removeBlocked(topics)
+	if err != nil {
+		logger.Fatalf("Error while checking the activity on topics - %v\n", err)
+	}
+	if len(topics) == 0 {
+		logger.Info("All the topics are in use, pass --force to delete them anyway")
+		return
+	}
+	if d.maxTopics > 0 && len(topics) > d.maxTopics {
+		logger.Fatalf("Refusing to delete %d topics, which is more than max-topics %d\n", len(topics), d.maxTopics)
+	}
+	if d.dryRun {
+		logger.Infof("Dry run, %d topics would be deleted\n", len(topics))
+		return
+	}
+
+	if !d.yes && !d.userInput.AskForConfirmation("Do you really want to delete the above topics?") {
+		return
+	}
+
+	b, err := newBackup(d, topics)
+	if err != nil {
+		logger.Fatalf("Error while backing up topics, none of them were deleted - %v\n", err)
+	}
+	fileName, err := b.write(d.backupDir)
+	if err != nil {
+		logger.Fatalf("Error while backing up topics, none of them were deleted - %v\n", err)
+	}
+	logger.Infof("Backed up the topics to %v, run kat topic restore --backup-file %v to recreate them\n", fileName, fileName)
+
+	err = d.deleteInBatches(topics)
+	if err != nil {
+		logger.Fatalf("Error while deleting topics - %v\n", err)
+	}
+}
+
+// deleteInBatches deletes the topics batch by batch, waiting for a batch to be removed from the metadata and for
+// the delay before deleting the next one. It stops at the first batch that fails.
+func (d *deleteTopic) deleteInBatches(topics []string) error {
+	batchSize := d.batchSize
+	if batchSize <= 0 {
+		batchSize = len(topics)
+	}
+
+	for start := 0; start < len(topics); start += batchSize {
+		end := start + batchSize
+		if end > len(topics) {
+			end = len(topics)
+		}
+		if err := d.Delete(topics[start:end]); err != nil {
+			if end < len(topics) {
+				return fmt.Errorf("%v, the remaining %d topics were not attempted", err, len(topics)-end)
+			}
+			return err
+		}
+		if end == len(topics) {
+			break
+		}
+
+		if err := d.waitForDeletion(topics[start:end]); err != nil {
+			return fmt.Errorf("%v, the remaining %d topics were not attempted", err, len(topics)-end)
+		}
+		logger.Infof("Deleted %d of %d topics\n", end, len(topics))
+		time.Sleep(d.delay)
+	}
+	return nil
+}
+
+// waitForDeletion polls the metadata until none of the topics are present in it
+func (d *deleteTopic) waitForDeletion(topics []string) error {
+	deadline := time.Now().Add(d.batchTimeout)
+	for {
+		topicDetails, err := d.List()
+		if err != nil {
+			return err
+		}
+		var pending []string
+		for _, topic := range topics {
+			if _, ok := topicDetails[topic]; ok {
+				pending = append(pending, topic)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the deletion of %v", strings.Join(pending, ", "))
+		}
+		time.Sleep(d.pollInterval)
+	}
+}
+
+// removeBlocked shows the topics with the blockers found for them, and leaves out the blocked topics unless forced
+func (d *deleteTopic) removeBlocked(topics []string) ([]string, error) {
+	blockers, err := findBlockers(d, topics, d.recentWriteWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletable []string
+	tw := &ui.TableWriter{}
 	for _, topic := range topics {
-		fmt.Println(topic)
+		action := "Delete"
+		if len(blockers[topic]) != 0 {
+			action = "Skip"
+			if d.force {
+				action = "Delete (forced)"
+			}
+		}
+		if action != "Skip" {
+			deletable = append(deletable, topic)
+		}
+		tw.AddRow(ui.TopicDeletion(topic, blockers[topic], action))
 	}
-	fmt.Println("------------------------------------------------------------")
-	confirmDelete := d.userInput.AskForConfirmation("Do you really want to delete the above topics?")
-	if confirmDelete {
-		err = d.Delete(topics)
+	tw.Render()
+	return deletable, nil
+}
+
+// removeProtected leaves out the internal topics and the topics matching any of the protected patterns
+func (d *deleteTopic) removeProtected(topics []string) ([]string, error) {
+	var regexes []*regexp.Regexp
+	for _, pattern := range append(append([]string{}, defaultProtectedTopics...), d.protected...) {
+		regex, err := regexp.Compile(pattern)
 		if err != nil {
-			logger.Fatalf("Error while deleting topics - %v\n", err)
+			return nil, fmt.Errorf("invalid protected topics regex %v - %v", pattern, err)
 		}
+		regexes = append(regexes, regex)
 	}
+
+	var deletable []string
+	for _, topic := range topics {
+		protected := false
+		for _, regex := range regexes {
+			if regex.MatchString(topic) {
+				protected = true
+				break
+			}
+		}
+		if protected {
+			logger.Warnf("Topic %v is protected, skipping\n", topic)
+			continue
+		}
+		deletable = append(deletable, topic)
+	}
+	return deletable, nil
 }
 
 func (d *deleteTopic) filterCriteria() (regex string, include bool, err error) {
@@ -108,7 +301,16 @@ func (d *deleteTopic) filterCriteria() (regex string, include bool, err error) {
 }
 
 func (d *deleteTopic) getLastWrittenTopics() ([]string, error) {
-	topics, err := d.ListLastWrittenTopics(d.lastWrite, d.dataDir)
+	var topics []string
+	var err error
+	switch d.method {
+	case base.LastWriteMethodSSH:
+		topics, err = d.ListLastWrittenTopics(d.lastWrite, d.dataDir, d.allowPartial)
+	case base.LastWriteMethodAPI:
+		topics, err = d.ListLastWrittenTopicsByTimestamp(d.lastWrite)
+	default:
+		err = fmt.Errorf("unknown method %v, should be one of ssh or api", d.method)
+	}
 	if err != nil {
 		logger.Errorf("Error while fetching topic list - %v\n", err)
 		return nil, err
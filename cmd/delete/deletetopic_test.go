@@ -2,9 +2,13 @@ package delete
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/pkg/client"
 
 	"github.com/gojek/kat/logger"
@@ -12,6 +16,7 @@ import (
 	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
@@ -28,7 +33,7 @@ func TestDelete_ReturnWhenWhiteListAndBlackListAreEmpty(t *testing.T) {
 	d := deleteTopic{Lister: mockLister}
 	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
 	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 }
 
@@ -42,7 +47,7 @@ func TestDelete_ReturnWhenWhiteListAndBlackListAreBothPassed(t *testing.T) {
 	d := deleteTopic{Lister: mockLister, topicWhitelist: "some", topicBlacklist: "some"}
 	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
 	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 }
 
@@ -50,17 +55,22 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesWhiteListedTopicsOnConfirmation(
 	mockLister := &client.MockLister{}
 	mockDeleter := &client.MockDeleter{}
 	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicWhitelist: "test-1|test-2", userInput: mockUserInput}
 	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
 	mockDeleter.On("Delete", topics).Return(nil)
+	expectBackup(mockLister, mockConfigurer, topics)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
 	d.deleteTopic()
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
+	assertBackedUp(t, backupDir, topics)
 	mockUserInput.AssertExpectations(t)
 }
 
@@ -70,12 +80,12 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesWhiteListedTopicsOnNo(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicWhitelist: "test-1|test-2", userInput: mockUserInput}
 	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockDeleter.AssertNotCalled(t, "Delete", topics)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
@@ -86,17 +96,22 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesBlackListedTopicsOnConfirmation(
 	mockLister := &client.MockLister{}
 	mockDeleter := &client.MockDeleter{}
 	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicBlacklist: "test-1|test-2", userInput: mockUserInput}
 	mockLister.On("ListOnly", d.topicBlacklist, false).Return(topics, nil)
 	mockDeleter.On("Delete", topics).Return(nil)
+	expectBackup(mockLister, mockConfigurer, topics)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
 	d.deleteTopic()
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
+	assertBackedUp(t, backupDir, topics)
 	mockUserInput.AssertExpectations(t)
 }
 
@@ -106,12 +121,12 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesBlackListedTopicsOnNo(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicBlacklist: "test-1|test-2", userInput: mockUserInput}
 	mockLister.On("ListOnly", d.topicBlacklist, false).Return(topics, nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
 	mockDeleter.AssertNotCalled(t, "Delete", topics)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
@@ -122,17 +137,44 @@ func TestDelete_WhenLastWriteIsPassed_DeletesWhiteListedTopicsOnConfirmation(t *
 	mockLister := &client.MockLister{}
 	mockDeleter := &client.MockDeleter{}
 	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
 	topics := []string{"test-3", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
-	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodSSH}
+	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir, d.allowPartial).Return(topics, nil)
 	mockDeleter.On("Delete", []string{"test-2"}).Return(nil)
+	expectBackup(mockLister, mockConfigurer, []string{"test-2"})
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
 	d.deleteTopic()
 	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
+	assertBackedUp(t, backupDir, []string{"test-2"})
+	mockUserInput.AssertExpectations(t)
+}
+
+func TestDelete_WhenLastWriteIsPassedWithAPIMethod_DeletesWhiteListedTopicsOnConfirmation(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodAPI}
+	mockLister.On("ListLastWrittenTopicsByTimestamp", d.lastWrite).Return([]string{"test-2", "test-3"}, nil)
+	mockDeleter.On("Delete", []string{"test-2"}).Return(nil)
+	expectBackup(mockLister, mockConfigurer, []string{"test-2"})
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+
+	d.deleteTopic()
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
+	mockLister.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+	assertBackedUp(t, backupDir, []string{"test-2"})
 	mockUserInput.AssertExpectations(t)
 }
 
@@ -142,8 +184,8 @@ func TestDelete_WhenLastWriteIsPassed_DeletesWhiteListedTopicsOnNo(t *testing.T)
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
-	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodSSH}
+	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir, d.allowPartial).Return(topics, nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -158,17 +200,22 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnConfirmation(t *
 	mockLister := &client.MockLister{}
 	mockDeleter := &client.MockDeleter{}
 	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
 	topics := []string{"test-3", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
-	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodSSH}
+	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir, d.allowPartial).Return(topics, nil)
 	mockDeleter.On("Delete", []string{"test-3"}).Return(nil)
+	expectBackup(mockLister, mockConfigurer, []string{"test-3"})
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
 	d.deleteTopic()
 	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 	mockDeleter.AssertExpectations(t)
+	assertBackedUp(t, backupDir, []string{"test-3"})
 	mockUserInput.AssertExpectations(t)
 }
 
@@ -178,8 +225,8 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnNo(t *testing.T)
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
-	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodSSH}
+	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir, d.allowPartial).Return(topics, nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -196,8 +243,8 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnError(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
-	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, errors.New("test"))
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123, method: base.LastWriteMethodSSH}
+	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir, d.allowPartial).Return(topics, errors.New("test"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -213,6 +260,274 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnError(t *testing
 	mockUserInput.AssertExpectations(t)
 }
 
+func TestDelete_DryRunDoesNotAskOrDelete(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicWhitelist: "test", userInput: mockUserInput, dryRun: true}
+	mockLister.On("ListOnly", d.topicWhitelist, true).Return([]string{"test-1"}, nil)
+
+	d.deleteTopic()
+	mockUserInput.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+	mockLister.AssertExpectations(t)
+}
+
+func TestDelete_SkipsProtectedTopics(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicBlacklist: "^orders$",
+		protected: []string{"^audit-"}, userInput: mockUserInput}
+	mockLister.On("ListOnly", d.topicBlacklist, false).Return([]string{"__consumer_offsets", "_schemas", "audit-log", "test-1"}, nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	mockDeleter.On("Delete", []string{"test-1"}).Return(nil)
+	expectBackup(mockLister, mockConfigurer, []string{"test-1"})
+
+	d.deleteTopic()
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_DoesNotDeleteWhenBackupFails(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"test-1"}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, topicWhitelist: "test", userInput: mockUserInput}
+	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
+	mockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_ExitsWhenDeleteFails(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
+	topics := []string{"test-1"}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), Configurer: mockConfigurer, backupDir: backupDir, topicWhitelist: "test", userInput: mockUserInput}
+	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	mockDeleter.On("Delete", topics).Return(errors.New("error"))
+	expectBackup(mockLister, mockConfigurer, topics)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_SkipsTopicsInUseUnlessForced(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	mockOffsetReader := &client.MockOffsetReader{}
+	mockGroupOffsetter := &client.MockGroupOffsetter{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
+	topics := []string{"consumed", "committed", "written", "unused"}
+
+	mockLister.On("ListOnly", "test", true).Return(topics, nil)
+	mockLister.On("List").Return(map[string]client.TopicDetail{
+		"consumed": {NumPartitions: 1}, "committed": {NumPartitions: 1}, "written": {NumPartitions: 2}, "unused": {NumPartitions: 1},
+	}, nil)
+	mockGroupOffsetter.On("ListGroups", ".*").Return([]string{"group-1", "group-2"}, nil)
+	mockGroupOffsetter.On("GetOffsets", "group-1").Return(map[string]map[int32]int64{"consumed": {0: 10}}, nil)
+	mockGroupOffsetter.On("GetOffsets", "group-2").Return(map[string]map[int32]int64{"committed": {0: 10}, "other": {0: 5}}, nil)
+	mockGroupOffsetter.On("ListGroupsForTopics", topics).Return(map[string][]string{"consumed": {"group-1"}}, nil)
+	mockOffsetReader.On("GetOffset", "written", int32(0), mock.Anything).Return(int64(-1), nil)
+	mockOffsetReader.On("GetOffset", "written", int32(1), mock.Anything).Return(int64(42), nil)
+	mockOffsetReader.On("GetOffset", mock.Anything, int32(0), mock.Anything).Return(int64(-1), nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	mockConfigurer.On("GetConfigs", mock.Anything).Return(map[string][]client.ConfigEntry{}, nil)
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, Configurer: mockConfigurer, OffsetReader: mockOffsetReader, GroupOffsetter: mockGroupOffsetter,
+		backupDir: backupDir, topicWhitelist: "test", recentWriteWindow: time.Hour, userInput: mockUserInput}
+	blockers, err := findBlockers(&d, topics, d.recentWriteWindow)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"consumed":  {"consumed by group group-1"},
+		"committed": {"offsets committed by group group-2"},
+		"written":   {"records written in the last 1h0m0s"},
+	}, blockers)
+
+	mockDeleter.On("Delete", []string{"unused"}).Return(nil).Once()
+	d.deleteTopic()
+
+	d.force = true
+	mockDeleter.On("Delete", topics).Return(nil).Once()
+	d.deleteTopic()
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_DoesNotAskWhenAllTopicsAreInUse(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockGroupOffsetter := &client.MockGroupOffsetter{}
+
+	mockLister.On("ListOnly", "test", true).Return([]string{"test-1"}, nil)
+	mockGroupOffsetter.On("ListGroups", ".*").Return([]string{"group-1"}, nil)
+	mockGroupOffsetter.On("GetOffsets", "group-1").Return(map[string]map[int32]int64{}, nil)
+	mockGroupOffsetter.On("ListGroupsForTopics", []string{"test-1"}).Return(map[string][]string{"test-1": {"group-1"}}, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: mockGroupOffsetter, topicWhitelist: "test", userInput: mockUserInput}
+
+	d.deleteTopic()
+	mockUserInput.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_TakesTopicsAsInUseWhenOffsetsOfAGroupCannotBeFetched(t *testing.T) {
+	mockGroupOffsetter := &client.MockGroupOffsetter{}
+	topics := []string{"test-1", "test-2"}
+
+	mockGroupOffsetter.On("ListGroups", ".*").Return([]string{"group-1", "group-2"}, nil)
+	mockGroupOffsetter.On("GetOffsets", "group-1").Return(map[string]map[int32]int64(nil), errors.New("error"))
+	mockGroupOffsetter.On("GetOffsets", "group-2").Return(map[string]map[int32]int64{"test-2": {0: 10}}, nil)
+	mockGroupOffsetter.On("ListGroupsForTopics", topics).Return(map[string][]string{}, nil)
+	d := deleteTopic{GroupOffsetter: mockGroupOffsetter}
+
+	blockers, err := findBlockers(&d, topics, 0)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"test-1": {"offsets of group group-1 could not be fetched"},
+		"test-2": {"offsets of group group-1 could not be fetched", "offsets committed by group group-2"},
+	}, blockers)
+	mockGroupOffsetter.AssertExpectations(t)
+}
+
+func noActivity() *client.MockGroupOffsetter {
+	mockGroupOffsetter := &client.MockGroupOffsetter{}
+	mockGroupOffsetter.On("ListGroups", ".*").Return([]string{}, nil)
+	mockGroupOffsetter.On("ListGroupsForTopics", mock.Anything).Return(map[string][]string{}, nil)
+	return mockGroupOffsetter
+}
+
+func TestDelete_WithYesDoesNotAskForConfirmation(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	mockConfigurer := &client.MockConfigurer{}
+	backupDir := tempDir(t)
+	defer os.RemoveAll(backupDir)
+	topics := []string{"test-1"}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, Configurer: mockConfigurer, GroupOffsetter: noActivity(), backupDir: backupDir,
+		topicWhitelist: "test", yes: true, userInput: mockUserInput}
+	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
+	mockDeleter.On("Delete", topics).Return(nil)
+	expectBackup(mockLister, mockConfigurer, topics)
+
+	d.deleteTopic()
+	mockUserInput.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_ExitsWhenTopicsAreMoreThanMaxTopics(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, GroupOffsetter: noActivity(), topicWhitelist: "test", maxTopics: 2, yes: true, userInput: mockUserInput}
+	mockLister.On("ListOnly", d.topicWhitelist, true).Return([]string{"test-1", "test-2", "test-3"}, nil)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_DeletesInBatchesWaitingForMetadata(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, batchSize: 2, batchTimeout: time.Minute}
+	mockDeleter.On("Delete", []string{"test-1", "test-2"}).Return(nil).Once()
+	mockLister.On("List").Return(map[string]client.TopicDetail{"test-2": {}, "test-3": {}}, nil).Once()
+	mockLister.On("List").Return(map[string]client.TopicDetail{"test-3": {}}, nil).Once()
+	mockDeleter.On("Delete", []string{"test-3"}).Return(nil).Once()
+
+	err := d.deleteInBatches([]string{"test-1", "test-2", "test-3"})
+
+	assert.NoError(t, err)
+	mockLister.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_StopsAtTheFailedBatch(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, batchSize: 1, batchTimeout: time.Minute}
+	mockDeleter.On("Delete", []string{"test-1"}).Return(errors.New("not authorized"))
+
+	err := d.deleteInBatches([]string{"test-1", "test-2", "test-3"})
+
+	assert.EqualError(t, err, "not authorized, the remaining 2 topics were not attempted")
+	mockDeleter.AssertNotCalled(t, "Delete", []string{"test-2"})
+}
+
+func TestDelete_TimesOutWaitingForMetadata(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, batchSize: 1, batchTimeout: time.Millisecond, pollInterval: time.Millisecond}
+	mockDeleter.On("Delete", []string{"test-1"}).Return(nil)
+	mockLister.On("List").Return(map[string]client.TopicDetail{"test-1": {}}, nil)
+
+	err := d.deleteInBatches([]string{"test-1", "test-2"})
+
+	assert.EqualError(t, err, "timed out waiting for the deletion of test-1, the remaining 1 topics were not attempted")
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "kat-backup")
+	require.NoError(t, err)
+	return dir
+}
+
+func expectBackup(mockLister *client.MockLister, mockConfigurer *client.MockConfigurer, topics []string) {
+	topicDetails := make(map[string]client.TopicDetail)
+	topicConfigs := make(map[string][]client.ConfigEntry)
+	for _, topic := range topics {
+		topicDetails[topic] = client.TopicDetail{NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}}
+		topicConfigs[topic] = []client.ConfigEntry{{Name: "retention.ms", Value: "1000", Source: "Topic"}}
+	}
+	mockLister.On("List").Return(topicDetails, nil)
+	mockConfigurer.On("GetConfigs", topics).Return(topicConfigs, nil)
+}
+
+func assertBackedUp(t *testing.T, backupDir string, topics []string) {
+	files, err := filepath.Glob(filepath.Join(backupDir, "*.yaml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	b, err := readBackup(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, topics, b.topics())
+}
+
 type MockUserInput struct {
 	mock.Mock
 }
@@ -0,0 +1,100 @@
+package delete
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type restoreCli interface {
+	client.Lister
+	client.Creator
+}
+
+type restoreTopic struct {
+	restoreCli
+	backupFile       string
+	topics           string
+	ignoreAssignment bool
+	dryRun           bool
+}
+
+var RestoreTopicCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Recreates the topics from a backup taken before deleting them",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		r := restoreTopic{
+			restoreCli:       base.Init(cobraUtil).GetTopic(),
+			backupFile:       cobraUtil.GetStringArg("backup-file"),
+			topics:           cobraUtil.GetStringArg("topics"),
+			ignoreAssignment: cobraUtil.GetBoolArg("ignore-assignment"),
+			dryRun:           cobraUtil.GetBoolArg("dry-run"),
+		}
+		r.restore()
+	},
+}
+
+func init() {
+	RestoreTopicCmd.PersistentFlags().StringP("backup-file", "f", "", "Path to the backup file written while deleting the topics")
+	RestoreTopicCmd.PersistentFlags().StringP("topics", "t", ".*", "Regex to restore only the matching topics of the backup")
+	RestoreTopicCmd.PersistentFlags().Bool("ignore-assignment", false, "Let the controller assign the replicas instead of placing them on the brokers they were on")
+	RestoreTopicCmd.PersistentFlags().Bool("dry-run", false, "Validate the topics to be created without creating them")
+	if err := RestoreTopicCmd.MarkPersistentFlagRequired("backup-file"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (r *restoreTopic) restore() {
+	b, err := readBackup(r.backupFile)
+	if err != nil {
+		logger.Fatalf("Error while reading backup - %v\n", err)
+	}
+	if err := r.create(b); err != nil {
+		logger.Fatalf("Error while restoring topics - %v\n", err)
+	}
+}
+
+// create recreates the topics of the backup that match the regex, skipping the ones already present in the cluster
+func (r *restoreTopic) create(b *backup) error {
+	regex, err := regexp.Compile(r.topics)
+	if err != nil {
+		return fmt.Errorf("invalid topics regex %v - %v", r.topics, err)
+	}
+	existing, err := r.List()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, topic := range b.topics() {
+		if !regex.MatchString(topic) {
+			continue
+		}
+		if _, ok := existing[topic]; ok {
+			logger.Warnf("Topic %v already exists, skipping\n", topic)
+			continue
+		}
+
+		if err := r.Create(topic, b.Topics[topic].detail(r.ignoreAssignment), r.dryRun); err != nil {
+			logger.Errorf("Err while creating topic %v - %v\n", topic, err)
+			errs = append(errs, fmt.Sprintf("%v - %v", topic, err))
+			continue
+		}
+		if r.dryRun {
+			logger.Infof("Topic %v was successfully validated\n", topic)
+		} else {
+			logger.Infof("Restored topic - %v\n", topic)
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("err while creating topics: %v", strings.Join(errs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package delete
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRestoreCli struct {
+	*client.MockLister
+	*client.MockCreator
+}
+
+var topicsBackup = &backup{Topics: map[string]topicBackup{
+	"orders":   {Partitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}},
+	"payments": {Partitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {2}}},
+	"users":    {Partitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {3}}},
+}}
+
+func TestRestore_CreatesMissingTopicsMatchingTheRegex(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockCreator := &client.MockCreator{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{"orders": {}}, nil)
+	mockCreator.On("Create", "payments", topicsBackup.Topics["payments"].detail(false), true).Return(nil)
+	r := restoreTopic{restoreCli: mockRestoreCli{mockLister, mockCreator}, topics: "orders|payments", dryRun: true}
+
+	err := r.create(topicsBackup)
+
+	assert.NoError(t, err)
+	mockCreator.AssertNotCalled(t, "Create", "orders", mock.Anything, mock.Anything)
+	mockCreator.AssertNotCalled(t, "Create", "users", mock.Anything, mock.Anything)
+	mockCreator.AssertExpectations(t)
+}
+
+func TestRestore_ContinuesAfterFailures(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockCreator := &client.MockCreator{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	mockCreator.On("Create", "orders", mock.Anything, false).Return(errors.New("broker not available"))
+	mockCreator.On("Create", "payments", mock.Anything, false).Return(nil)
+	mockCreator.On("Create", "users", mock.Anything, false).Return(nil)
+	r := restoreTopic{restoreCli: mockRestoreCli{mockLister, mockCreator}, topics: ".*", ignoreAssignment: true}
+
+	err := r.create(topicsBackup)
+
+	assert.EqualError(t, err, "err while creating topics: orders - broker not available")
+	mockCreator.AssertExpectations(t)
+}
+
+func TestRestore_ExitsWhenBackupIsMissing(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restoreTopic{backupFile: "/non/existent/backup.yaml"}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restore, "os.Exit was not called")
+}
@@ -2,8 +2,11 @@ package describe
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,7 +16,12 @@ import (
 
 type describeTopic struct {
 	client.Describer
-	topics []string
+	client.Configurer
+	client.OffsetReader
+	client.GroupOffsetter
+	client.LogDirDescriber
+	topics   []string
+	detailed bool
 }
 
 var DescribeTopicCmd = &cobra.Command{
@@ -21,13 +29,24 @@ var DescribeTopicCmd = &cobra.Command{
 	Short: "Describes the given topic",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		d := describeTopic{Describer: base.Init(cobraUtil).GetTopic(), topics: cobraUtil.GetTopicNames()}
+		baseCmd := base.Init(cobraUtil)
+		d := describeTopic{
+			Describer:       baseCmd.GetTopic(),
+			Configurer:      baseCmd.GetTopic(),
+			OffsetReader:    baseCmd.GetTopic(),
+			GroupOffsetter:  baseCmd.GetConsumerGroup(),
+			LogDirDescriber: baseCmd.GetBroker(),
+			topics:          cobraUtil.GetTopicNames(),
+			detailed:        cobraUtil.GetBoolArg("detailed"),
+		}
 		d.describeTopic()
 	},
 }
 
 func init() {
 	DescribeTopicCmd.PersistentFlags().StringP("topics", "t", "", "Comma separated list of topic names to describe")
+	DescribeTopicCmd.PersistentFlags().BoolP("detailed", "d", false,
+		"Show the offsets, size and health of the partitions, with the config overrides and consumer groups of the topics")
 	if err := DescribeTopicCmd.MarkPersistentFlagRequired("topics"); err != nil {
 		logger.Fatal(err)
 	}
@@ -38,7 +57,13 @@ func (d *describeTopic) describeTopic() {
 	if err != nil {
 		logger.Fatalf("Error while retrieving topic metadata - %v\n", err)
 	}
-	printConfigs(metadata)
+	if !d.detailed {
+		printConfigs(metadata)
+		return
+	}
+	if err := d.printDetails(metadata); err != nil {
+		logger.Fatalf("Error while describing topics - %v\n", err)
+	}
 }
 
 func printConfigs(metadata []*client.TopicMetadata) {
@@ -54,3 +79,134 @@ func printConfigs(metadata []*client.TopicMetadata) {
 		fmt.Println()
 	}
 }
+
+// printDetails prints a header with the overrides, consumer groups and health of every topic, followed by a table of
+// its partitions
+func (d *describeTopic) printDetails(metadata []*client.TopicMetadata) error {
+	topicConfigs, err := d.GetConfigs(d.topics)
+	if err != nil {
+		return err
+	}
+	sizes := d.partitionSizes()
+	topicGroups, err := d.ListGroupsForTopics(d.topics)
+	if err != nil {
+		return fmt.Errorf("err while listing consumer groups - %v", err)
+	}
+
+	for _, topicMetadata := range metadata {
+		if topicMetadata.Err != nil {
+			logger.Errorf("Err while describing topic %v - %v\n", topicMetadata.Name, topicMetadata.Err)
+			continue
+		}
+		partitions := append([]*client.PartitionMetadata{}, topicMetadata.Partitions...)
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i].ID < partitions[j].ID })
+
+		tw := &ui.TableWriter{}
+		underReplicated := 0
+		for _, partition := range partitions {
+			earliest, latest := d.partitionOffsets(topicMetadata.Name, partition.ID)
+			if len(partition.Isr) < len(partition.Replicas) {
+				underReplicated++
+			}
+			tw.AddRow(ui.PartitionDescription(partition.ID, partition.Leader, partition.Replicas, partition.Isr, partition.OfflineReplicas,
+				earliest, latest, partitionSize(sizes, topicMetadata.Name, partition)))
+		}
+
+		replicationFactor := 0
+		if len(partitions) != 0 {
+			replicationFactor = len(partitions[0].Replicas)
+		}
+		fmt.Printf("Topic: %v\nInternal: %v\nPartitions: %v\nReplication Factor: %v\nUnder Replicated Partitions: %v\n",
+			topicMetadata.Name, topicMetadata.IsInternal, len(partitions), replicationFactor, underReplicated)
+		fmt.Printf("Config Overrides: %v\n", orNone(configOverrides(topicConfigs[topicMetadata.Name])))
+		fmt.Printf("Consumer Groups: %v\n", orNone(topicGroups[topicMetadata.Name]))
+		tw.Render()
+		fmt.Println()
+	}
+	return nil
+}
+
+// partitionOffsets returns the earliest and latest offsets of the partition, or -1 when they can not be fetched, as
+// for the partitions without a leader, so that the rest of the partitions are still described
+func (d *describeTopic) partitionOffsets(topic string, partition int32) (int64, int64) {
+	earliest, err := d.GetOffset(topic, partition, client.OffsetOldest)
+	if err != nil {
+		logger.Errorf("Err while fetching offset of %v-%v - %v\n", topic, partition, err)
+		return -1, -1
+	}
+	latest, err := d.GetOffset(topic, partition, client.OffsetNewest)
+	if err != nil {
+		logger.Errorf("Err while fetching offset of %v-%v - %v\n", topic, partition, err)
+		return -1, -1
+	}
+	return earliest, latest
+}
+
+// partitionSizes returns the size of the replicas of every partition by broker, from the online log dirs. The sizes
+// are left out when the log dirs can not be described, as they are not needed to describe the topic
+func (d *describeTopic) partitionSizes() map[string]map[int32]map[int32]int64 {
+	logDirs, err := d.DescribeLogDirs(nil)
+	if err != nil {
+		logger.Warnf("Sizes of the partitions are not shown, err while describing log dirs - %v\n", err)
+		return nil
+	}
+
+	sizes := make(map[string]map[int32]map[int32]int64)
+	for _, logDir := range logDirs {
+		if logDir.Err != nil {
+			continue
+		}
+		for _, replica := range logDir.Replicas {
+			if replica.IsTemporary {
+				continue
+			}
+			if sizes[replica.Topic] == nil {
+				sizes[replica.Topic] = make(map[int32]map[int32]int64)
+			}
+			if sizes[replica.Topic][replica.Partition] == nil {
+				sizes[replica.Topic][replica.Partition] = make(map[int32]int64)
+			}
+			sizes[replica.Topic][replica.Partition][logDir.Broker] = replica.Size
+		}
+	}
+	return sizes
+}
+
+// partitionSize returns the size of the leader replica, or of the largest replica when the leader is not known, and
+// -1 when none of the replicas are found
+func partitionSize(sizes map[string]map[int32]map[int32]int64, topic string, partition *client.PartitionMetadata) int64 {
+	replicaSizes := sizes[topic][partition.ID]
+	if size, ok := replicaSizes[partition.Leader]; ok {
+		return size
+	}
+	size := int64(-1)
+	for _, replicaSize := range replicaSizes {
+		if replicaSize > size {
+			size = replicaSize
+		}
+	}
+	return size
+}
+
+func configOverrides(entries []client.ConfigEntry) []string {
+	var overrides []string
+	for _, entry := range entries {
+		if !entry.IsTopicOverride() {
+			continue
+		}
+		value := entry.Value
+		if entry.Sensitive {
+			value = "(sensitive)"
+		}
+		overrides = append(overrides, fmt.Sprintf("%v=%v", entry.Name, value))
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+func orNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
@@ -8,6 +8,7 @@ import (
 	"bou.ke/monkey"
 	"github.com/gojek/kat/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/gojek/kat/pkg/client"
 )
@@ -38,3 +39,121 @@ func TestDescribe_Failure(t *testing.T) {
 	assert.PanicsWithValue(t, "os.Exit called", d.describeTopic, "os.Exit was not called")
 	mockDescriber.AssertExpectations(t)
 }
+
+type mockDetailsCli struct {
+	*client.MockDescriber
+	*client.MockConfigurer
+	*client.MockOffsetReader
+	*client.MockGroupOffsetter
+	*client.MockLogDirDescriber
+}
+
+func newDetailedDescribeTopic() (describeTopic, mockDetailsCli) {
+	cli := mockDetailsCli{&client.MockDescriber{}, &client.MockConfigurer{}, &client.MockOffsetReader{}, &client.MockGroupOffsetter{},
+		&client.MockLogDirDescriber{}}
+	topics := []string{"topic1"}
+	cli.MockDescriber.On("Describe", topics).Return([]*client.TopicMetadata{{Name: "topic1", Partitions: []*client.PartitionMetadata{
+		{ID: 1, Leader: 2, Replicas: []int32{1, 2}, Isr: []int32{2}},
+		{ID: 0, Leader: 1, Replicas: []int32{1, 2}, Isr: []int32{1, 2}},
+	}}}, nil)
+	cli.MockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{"topic1": {
+		{Name: "retention.ms", Value: "1000", Source: "Topic"},
+		{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+	}}, nil)
+	cli.MockGroupOffsetter.On("ListGroupsForTopics", topics).Return(map[string][]string{"topic1": {"group1"}}, nil)
+	for partition := int32(0); partition < 2; partition++ {
+		cli.MockOffsetReader.On("GetOffset", "topic1", partition, client.OffsetOldest).Return(int64(10), nil)
+		cli.MockOffsetReader.On("GetOffset", "topic1", partition, client.OffsetNewest).Return(int64(100), nil)
+	}
+	d := describeTopic{Describer: cli, Configurer: cli, OffsetReader: cli, GroupOffsetter: cli, LogDirDescriber: cli, topics: topics, detailed: true}
+	return d, cli
+}
+
+func TestDescribe_Detailed(t *testing.T) {
+	d, cli := newDetailedDescribeTopic()
+	cli.MockLogDirDescriber.On("DescribeLogDirs", []int32(nil)).Return([]client.LogDir{
+		{Broker: 1, Replicas: []client.ReplicaLog{{Topic: "topic1", Partition: 0, Size: 2048}}},
+	}, nil)
+
+	d.describeTopic()
+
+	cli.MockConfigurer.AssertExpectations(t)
+	cli.MockOffsetReader.AssertExpectations(t)
+	cli.MockGroupOffsetter.AssertExpectations(t)
+	cli.MockLogDirDescriber.AssertExpectations(t)
+}
+
+func TestDescribe_DetailedWithoutLogDirs(t *testing.T) {
+	d, cli := newDetailedDescribeTopic()
+	cli.MockLogDirDescriber.On("DescribeLogDirs", []int32(nil)).Return([]client.LogDir{}, errors.New("error"))
+
+	d.describeTopic()
+
+	cli.MockOffsetReader.AssertExpectations(t)
+}
+
+func TestDescribe_DetailedContinuesOnOffsetFailure(t *testing.T) {
+	cli := mockDetailsCli{&client.MockDescriber{}, &client.MockConfigurer{}, &client.MockOffsetReader{}, &client.MockGroupOffsetter{},
+		&client.MockLogDirDescriber{}}
+	topics := []string{"topic1"}
+	cli.MockDescriber.On("Describe", topics).Return([]*client.TopicMetadata{{Name: "topic1", Partitions: []*client.PartitionMetadata{
+		{ID: 0, Leader: -1, Replicas: []int32{1}, OfflineReplicas: []int32{1}},
+		{ID: 1, Leader: 2, Replicas: []int32{2}, Isr: []int32{2}},
+	}}}, nil)
+	cli.MockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	cli.MockLogDirDescriber.On("DescribeLogDirs", []int32(nil)).Return([]client.LogDir{}, nil)
+	cli.MockGroupOffsetter.On("ListGroupsForTopics", topics).Return(map[string][]string{}, nil)
+	cli.MockOffsetReader.On("GetOffset", "topic1", int32(0), client.OffsetOldest).Return(int64(0), errors.New("leader not available"))
+	cli.MockOffsetReader.On("GetOffset", "topic1", int32(1), client.OffsetOldest).Return(int64(10), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic1", int32(1), client.OffsetNewest).Return(int64(100), nil)
+	d := describeTopic{Describer: cli, Configurer: cli, OffsetReader: cli, GroupOffsetter: cli, LogDirDescriber: cli, topics: topics, detailed: true}
+
+	d.describeTopic()
+
+	cli.MockOffsetReader.AssertExpectations(t)
+	cli.MockOffsetReader.AssertNotCalled(t, "GetOffset", "topic1", int32(0), client.OffsetNewest)
+	earliest, latest := d.partitionOffsets("topic1", 0)
+	assert.Equal(t, int64(-1), earliest)
+	assert.Equal(t, int64(-1), latest)
+}
+
+func TestDescribe_DetailedGroupsFailure(t *testing.T) {
+	cli := mockDetailsCli{&client.MockDescriber{}, &client.MockConfigurer{}, &client.MockOffsetReader{}, &client.MockGroupOffsetter{},
+		&client.MockLogDirDescriber{}}
+	topics := []string{"topic1"}
+	cli.MockDescriber.On("Describe", topics).Return([]*client.TopicMetadata{{Name: "topic1", Partitions: []*client.PartitionMetadata{{ID: 0}}}}, nil)
+	cli.MockConfigurer.On("GetConfigs", topics).Return(map[string][]client.ConfigEntry{}, nil)
+	cli.MockLogDirDescriber.On("DescribeLogDirs", []int32(nil)).Return([]client.LogDir{}, nil)
+	cli.MockGroupOffsetter.On("ListGroupsForTopics", topics).Return(map[string][]string{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := describeTopic{Describer: cli, Configurer: cli, OffsetReader: cli, GroupOffsetter: cli, LogDirDescriber: cli, topics: topics, detailed: true}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.describeTopic, "os.Exit was not called")
+	cli.MockOffsetReader.AssertNotCalled(t, "GetOffset", "topic1", int32(0), client.OffsetOldest)
+}
+
+func TestPartitionSize(t *testing.T) {
+	sizes := map[string]map[int32]map[int32]int64{"topic1": {0: {1: 100, 2: 200}}}
+
+	assert.Equal(t, int64(100), partitionSize(sizes, "topic1", &client.PartitionMetadata{ID: 0, Leader: 1}))
+	assert.Equal(t, int64(200), partitionSize(sizes, "topic1", &client.PartitionMetadata{ID: 0, Leader: -1}))
+	assert.Equal(t, int64(-1), partitionSize(sizes, "topic1", &client.PartitionMetadata{ID: 1, Leader: 1}))
+	assert.Equal(t, int64(-1), partitionSize(nil, "topic1", &client.PartitionMetadata{ID: 0, Leader: 1}))
+}
+
+func TestConfigOverrides(t *testing.T) {
+	overrides := configOverrides([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: "Topic"},
+		{Name: "cleanup.policy", Value: "compact", Source: "Topic"},
+		{Name: "sasl.jaas.config", Value: "secret", Source: "Topic", Sensitive: true},
+		{Name: "segment.bytes", Value: "100", Source: "StaticBroker"},
+	})
+
+	require.Len(t, overrides, 3)
+	assert.Equal(t, []string{"cleanup.policy=compact", "retention.ms=1000", "sasl.jaas.config=(sensitive)"}, overrides)
+	assert.Equal(t, "none", orNone(nil))
+}
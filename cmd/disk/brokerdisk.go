@@ -0,0 +1,96 @@
+package disk
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	groupByBroker = "broker"
+	groupByLogDir = "log-dir"
+)
+
+type brokerDisk struct {
+	client.LogDirDescriber
+	brokerID int
+	groupBy  string
+	sortBy   string
+	top      int
+}
+
+var BrokerDiskCmd = &cobra.Command{
+	Use:   "disk",
+	Short: "Shows the disk used by the replicas on the brokers",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		b := brokerDisk{
+			LogDirDescriber: base.Init(cobraUtil).GetBroker(),
+			brokerID:        cobraUtil.GetIntArg("broker-id"),
+			groupBy:         cobraUtil.GetStringArg("group-by"),
+			sortBy:          cobraUtil.GetStringArg("sort"),
+			top:             cobraUtil.GetIntArg("top"),
+		}
+		b.disk()
+	},
+}
+
+func init() {
+	BrokerDiskCmd.PersistentFlags().Int("broker-id", -1, "Id of the broker, all the brokers are shown when not passed")
+	BrokerDiskCmd.PersistentFlags().String("group-by", groupByBroker, "Group the disk usage by broker or log-dir")
+	BrokerDiskCmd.PersistentFlags().String("sort", sortBySize, "Sort by size (largest first) or name")
+	BrokerDiskCmd.PersistentFlags().Int("top", 0, "Show only the first n rows after sorting")
+}
+
+func (b *brokerDisk) disk() {
+	u, err := b.usages()
+	if err != nil {
+		logger.Fatalf("Error while computing broker disk usage - %v\n", err)
+	}
+	u.render()
+}
+
+func (b *brokerDisk) usages() (*usages, error) {
+	var u *usages
+	switch b.groupBy {
+	case groupByBroker:
+		u = newUsages("Broker")
+	case groupByLogDir:
+		u = newUsages("Broker", "Log Dir")
+	default:
+		return nil, fmt.Errorf("invalid group-by %v, expected one of %v, %v", b.groupBy, groupByBroker, groupByLogDir)
+	}
+
+	var brokerIDs []int32
+	if b.brokerID >= 0 {
+		brokerIDs = []int32{int32(b.brokerID)}
+	}
+	logDirs, err := b.DescribeLogDirs(brokerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, logDir := range logDirs {
+		if logDir.Err != nil {
+			logger.Errorf("Err in log dir %v of broker %v - %v\n", logDir.Path, logDir.Broker, logDir.Err)
+			continue
+		}
+		var size int64
+		for _, replica := range logDir.Replicas {
+			size += replica.Size
+		}
+		if b.groupBy == groupByLogDir {
+			u.add(size, fmt.Sprint(logDir.Broker), logDir.Path)
+		} else {
+			u.add(size, fmt.Sprint(logDir.Broker))
+		}
+	}
+
+	if err := u.sortAndLimit(b.sortBy, b.top); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
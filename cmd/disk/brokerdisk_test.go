@@ -0,0 +1,34 @@
+package disk
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerDisk_GroupByBroker(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return(logDirs, nil)
+	b := brokerDisk{LogDirDescriber: describer, brokerID: -1, groupBy: groupByBroker, sortBy: sortBySize}
+
+	u, err := b.usages()
+
+	require.NoError(t, err)
+	assert.Equal(t, []usage{{values: []string{"2"}, size: 1220}, {values: []string{"1"}, size: 450}}, u.rows)
+	describer.AssertExpectations(t)
+}
+
+func TestBrokerDisk_GroupByLogDirOfBroker(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32{1}).Return(logDirs[:2], nil)
+	b := brokerDisk{LogDirDescriber: describer, brokerID: 1, groupBy: groupByLogDir, sortBy: sortByName}
+
+	u, err := b.usages()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Broker", "Log Dir"}, u.headers)
+	assert.Equal(t, []usage{{values: []string{"1", "/data/1"}, size: 450}}, u.rows)
+	describer.AssertExpectations(t)
+}
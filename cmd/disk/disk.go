@@ -0,0 +1,89 @@
+package disk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gojek/kat/ui"
+)
+
+const (
+	sortBySize = "size"
+	sortByName = "name"
+)
+
+// usage is the disk used by the replicas grouped under the values, eg: topic, partition, broker or log dir
+type usage struct {
+	values []string
+	size   int64
+}
+
+type usages struct {
+	headers []string
+	index   map[string]int
+	rows    []usage
+}
+
+func newUsages(headers ...string) *usages {
+	return &usages{headers: headers, index: make(map[string]int)}
+}
+
+func (u *usages) add(size int64, values ...string) {
+	key := fmt.Sprint(values)
+	i, ok := u.index[key]
+	if !ok {
+		i = len(u.rows)
+		u.index[key] = i
+		u.rows = append(u.rows, usage{values: values})
+	}
+	u.rows[i].size += size
+}
+
+// sortAndLimit sorts the usages by size in descending order or by name, and keeps the first top of them when top is positive
+func (u *usages) sortAndLimit(sortBy string, top int) error {
+	switch sortBy {
+	case sortBySize:
+		sort.SliceStable(u.rows, func(i, j int) bool {
+			if u.rows[i].size != u.rows[j].size {
+				return u.rows[i].size > u.rows[j].size
+			}
+			return lessValues(u.rows[i].values, u.rows[j].values)
+		})
+	case sortByName:
+		sort.SliceStable(u.rows, func(i, j int) bool {
+			return lessValues(u.rows[i].values, u.rows[j].values)
+		})
+	default:
+		return fmt.Errorf("invalid sort %v, expected one of %v, %v", sortBy, sortBySize, sortByName)
+	}
+
+	if top > 0 && top < len(u.rows) {
+		u.rows = u.rows[:top]
+	}
+	return nil
+}
+
+func (u *usages) render() {
+	tw := &ui.TableWriter{}
+	for _, row := range u.rows {
+		tw.AddRow(ui.DiskUsage(u.headers, row.values, row.size))
+	}
+	tw.Render()
+}
+
+// lessValues compares the values in order, numerically when both of them are numbers, eg: partitions and broker ids
+func lessValues(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, xErr := strconv.ParseInt(a[i], 10, 64)
+		y, yErr := strconv.ParseInt(b[i], 10, 64)
+		if xErr == nil && yErr == nil {
+			return x < y
+		}
+		return a[i] < b[i]
+	}
+	return len(a) < len(b)
+}
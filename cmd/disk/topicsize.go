@@ -0,0 +1,105 @@
+package disk
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	groupByTopic     = "topic"
+	groupByPartition = "partition"
+	groupByReplica   = "replica"
+)
+
+type topicSize struct {
+	client.LogDirDescriber
+	topics  string
+	groupBy string
+	sortBy  string
+	top     int
+}
+
+var TopicSizeCmd = &cobra.Command{
+	Use:   "size",
+	Short: "Shows the size on disk of the topics, summed across all the replicas",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		s := topicSize{
+			LogDirDescriber: base.Init(cobraUtil).GetBroker(),
+			topics:          cobraUtil.GetStringArg("topics"),
+			groupBy:         cobraUtil.GetStringArg("group-by"),
+			sortBy:          cobraUtil.GetStringArg("sort"),
+			top:             cobraUtil.GetIntArg("top"),
+		}
+		s.size()
+	},
+}
+
+func init() {
+	TopicSizeCmd.PersistentFlags().StringP("topics", "t", ".*", "Regex to match the topics")
+	TopicSizeCmd.PersistentFlags().String("group-by", groupByTopic, "Group the size by topic, partition or replica")
+	TopicSizeCmd.PersistentFlags().String("sort", sortBySize, "Sort by size (largest first) or name")
+	TopicSizeCmd.PersistentFlags().Int("top", 0, "Show only the first n rows after sorting")
+}
+
+func (s *topicSize) size() {
+	u, err := s.usages()
+	if err != nil {
+		logger.Fatalf("Error while computing topic size - %v\n", err)
+	}
+	u.render()
+}
+
+func (s *topicSize) usages() (*usages, error) {
+	regex, err := regexp.Compile(s.topics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topics regex %v - %v", s.topics, err)
+	}
+
+	var u *usages
+	switch s.groupBy {
+	case groupByTopic:
+		u = newUsages("Topic")
+	case groupByPartition:
+		u = newUsages("Topic", "Partition")
+	case groupByReplica:
+		u = newUsages("Topic", "Partition", "Broker", "Log Dir")
+	default:
+		return nil, fmt.Errorf("invalid group-by %v, expected one of %v, %v, %v", s.groupBy, groupByTopic, groupByPartition, groupByReplica)
+	}
+
+	logDirs, err := s.DescribeLogDirs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, logDir := range logDirs {
+		if logDir.Err != nil {
+			logger.Errorf("Err in log dir %v of broker %v, its replicas are not counted - %v\n", logDir.Path, logDir.Broker, logDir.Err)
+			continue
+		}
+		for _, replica := range logDir.Replicas {
+			if !regex.MatchString(replica.Topic) {
+				continue
+			}
+			switch s.groupBy {
+			case groupByTopic:
+				u.add(replica.Size, replica.Topic)
+			case groupByPartition:
+				u.add(replica.Size, replica.Topic, fmt.Sprint(replica.Partition))
+			case groupByReplica:
+				u.add(replica.Size, replica.Topic, fmt.Sprint(replica.Partition), fmt.Sprint(logDir.Broker), logDir.Path)
+			}
+		}
+	}
+
+	if err := u.sortAndLimit(s.sortBy, s.top); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
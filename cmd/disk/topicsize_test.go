@@ -0,0 +1,101 @@
+package disk
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+var logDirs = []client.LogDir{
+	{Broker: 1, Path: "/data/1", Replicas: []client.ReplicaLog{
+		{Topic: "orders", Partition: 0, Size: 100},
+		{Topic: "orders", Partition: 10, Size: 300},
+		{Topic: "payments", Partition: 0, Size: 50},
+	}},
+	{Broker: 1, Path: "/data/2", Err: errors.New("storage error")},
+	{Broker: 2, Path: "/data/1", Replicas: []client.ReplicaLog{
+		{Topic: "orders", Partition: 0, Size: 120},
+		{Topic: "orders", Partition: 2, Size: 40},
+		{Topic: "payments", Partition: 0, Size: 60},
+		{Topic: "__consumer_offsets", Partition: 0, Size: 1000},
+	}},
+}
+
+func TestTopicSize_GroupByTopic(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return(logDirs, nil)
+	s := topicSize{LogDirDescriber: describer, topics: "^[a-z]", groupBy: groupByTopic, sortBy: sortBySize}
+
+	u, err := s.usages()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Topic"}, u.headers)
+	assert.Equal(t, []usage{{values: []string{"orders"}, size: 560}, {values: []string{"payments"}, size: 110}}, u.rows)
+	describer.AssertExpectations(t)
+}
+
+func TestTopicSize_GroupByPartitionSortedByName(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return(logDirs, nil)
+	s := topicSize{LogDirDescriber: describer, topics: "orders", groupBy: groupByPartition, sortBy: sortByName}
+
+	u, err := s.usages()
+
+	require.NoError(t, err)
+	assert.Equal(t, []usage{
+		{values: []string{"orders", "0"}, size: 220},
+		{values: []string{"orders", "2"}, size: 40},
+		{values: []string{"orders", "10"}, size: 300},
+	}, u.rows)
+}
+
+func TestTopicSize_GroupByReplicaWithTop(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return(logDirs, nil)
+	s := topicSize{LogDirDescriber: describer, topics: ".*", groupBy: groupByReplica, sortBy: sortBySize, top: 2}
+
+	u, err := s.usages()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Topic", "Partition", "Broker", "Log Dir"}, u.headers)
+	assert.Equal(t, []usage{
+		{values: []string{"__consumer_offsets", "0", "2", "/data/1"}, size: 1000},
+		{values: []string{"orders", "10", "1", "/data/1"}, size: 300},
+	}, u.rows)
+}
+
+func TestTopicSize_InvalidArgs(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return(logDirs, nil)
+
+	_, err := (&topicSize{LogDirDescriber: describer, topics: "(", groupBy: groupByTopic, sortBy: sortBySize}).usages()
+	assert.Error(t, err)
+	_, err = (&topicSize{LogDirDescriber: describer, topics: ".*", groupBy: "broker", sortBy: sortBySize}).usages()
+	assert.Error(t, err)
+	_, err = (&topicSize{LogDirDescriber: describer, topics: ".*", groupBy: groupByTopic, sortBy: "bytes"}).usages()
+	assert.Error(t, err)
+}
+
+func TestTopicSize_Failure(t *testing.T) {
+	describer := &client.MockLogDirDescriber{}
+	describer.On("DescribeLogDirs", []int32(nil)).Return([]client.LogDir{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	s := topicSize{LogDirDescriber: describer, topics: ".*", groupBy: groupByTopic, sortBy: sortBySize}
+
+	assert.PanicsWithValue(t, "os.Exit called", s.size, "os.Exit was not called")
+	describer.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package list
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
@@ -38,25 +39,24 @@ func (c *consumerGroupAdmin) ListGroups(topic string) error {
 		return err
 	}
 
-	consumerGroupList := make([]string, len(consumerGroupsMap))
-	for group := range consumerGroupsMap {
-		consumerGroupList = append(consumerGroupList, group)
-	}
-
-	sort.Slice(consumerGroupList, func(i int, j int) bool {
-		return consumerGroupList[i] < consumerGroupList[j]
-	})
-
 	var consumerGroups []string
-
 	for consumerGroupID := range consumerGroupsMap {
 		consumerGroups = append(consumerGroups, consumerGroupID)
 	}
+	sort.Strings(consumerGroups)
 
-	_, err = c.saramaClient.GetConsumerGroupsForTopic(consumerGroups, topic)
+	consumerGroupsChannel, err := c.saramaClient.GetConsumerGroupsForTopic(consumerGroups, topic)
 	if err != nil {
 		return err
 	}
 
+	var subscribedGroups []string
+	for group := range consumerGroupsChannel {
+		subscribedGroups = append(subscribedGroups, group)
+	}
+	sort.Strings(subscribedGroups)
+	for _, group := range subscribedGroups {
+		fmt.Println(group)
+	}
 	return nil
 }
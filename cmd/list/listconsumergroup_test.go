@@ -24,7 +24,10 @@ func (m *mockConsumerListener) GetConsumerGroupsForTopic(consumerGroups []string
 func TestListGroupsReturnsSuccess(t *testing.T) {
 	mockConsumer := new(mockConsumerListener)
 	admin := consumerGroupAdmin{mockConsumer}
-	mockChannel := make(chan string, 0)
+	mockChannel := make(chan string, 2)
+	mockChannel <- "consumer2"
+	mockChannel <- "consumer1"
+	close(mockChannel)
 
 	consumerGroupsMap := map[string]string{"consumer1": "", "consumer2": ""}
 	mockConsumer.On("ListConsumerGroups").Return(consumerGroupsMap, nil)
@@ -53,12 +56,10 @@ func TestListGroupsReturnsFailureIfListConsumerGroupsFails(t *testing.T) {
 func TestListGroupsReturnsFailureIfGetConsumerGroupsFails(t *testing.T) {
 	mockConsumer := new(mockConsumerListener)
 	admin := consumerGroupAdmin{mockConsumer}
-	mockChannel := make(chan string, 0)
-
 	consumerGroupsMap := map[string]string{"consumer1": "", "consumer2": ""}
 	mockConsumer.On("ListConsumerGroups").Return(consumerGroupsMap, nil)
 
-	mockConsumer.On("GetConsumerGroupsForTopic", []string{"consumer1", "consumer2"}, "").Return(mockChannel, errors.New("get consumer groups failed"))
+	mockConsumer.On("GetConsumerGroupsForTopic", []string{"consumer1", "consumer2"}, "").Return((chan string)(nil), errors.New("get consumer groups failed"))
 
 	err := admin.ListGroups("")
 	require.Error(t, err)
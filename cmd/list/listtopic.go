@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -14,8 +15,21 @@ import (
 
 type listTopic struct {
 	client.Lister
+	client.Describer
+	client.Configurer
+	topics            string
 	replicationFactor int
+	minPartitions     int
+	maxPartitions     int
+	configs           []string
+	onlyInternal      bool
+	excludeInternal   bool
+	underReplicated   bool
+	sortBy            string
+	wide              bool
 	lastWrite         int64
+	method            string
+	allowPartial      bool
 	dataDir           string
 }
 
@@ -25,8 +39,9 @@ var ListTopicCmd = &cobra.Command{
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
 		lastWrite := int64(cobraUtil.GetIntArg("last-write"))
+		method := cobraUtil.GetStringArg("method")
 		var baseCmd *base.Cmd
-		if lastWrite == 0 {
+		if lastWrite == 0 || method == base.LastWriteMethodAPI {
 			baseCmd = base.Init(cobraUtil)
 		} else {
 			baseCmd = base.Init(cobraUtil, base.WithSSH())
@@ -34,8 +49,21 @@ var ListTopicCmd = &cobra.Command{
 
 		l := listTopic{
 			Lister:            baseCmd.GetTopic(),
+			Describer:         baseCmd.GetTopic(),
+			Configurer:        baseCmd.GetTopic(),
+			topics:            cobraUtil.GetStringArg("topics"),
 			replicationFactor: cobraUtil.GetIntArg("replication-factor"),
+			minPartitions:     cobraUtil.GetIntArg("min-partitions"),
+			maxPartitions:     cobraUtil.GetIntArg("max-partitions"),
+			configs:           cobraUtil.GetStringSliceArg("config"),
+			onlyInternal:      cobraUtil.GetBoolArg("internal"),
+			excludeInternal:   cobraUtil.GetBoolArg("exclude-internal"),
+			underReplicated:   cobraUtil.GetBoolArg("under-replicated"),
+			sortBy:            cobraUtil.GetStringArg("sort"),
+			wide:              cobraUtil.GetBoolArg("wide"),
 			lastWrite:         lastWrite,
+			method:            method,
+			allowPartial:      cobraUtil.GetBoolArg("allow-partial"),
 			dataDir:           cobraUtil.GetStringArg("data-dir"),
 		}
 		l.listTopic()
@@ -43,52 +71,85 @@ var ListTopicCmd = &cobra.Command{
 }
 
 func init() {
+	ListTopicCmd.PersistentFlags().StringP("topics", "t", "", "Regex to match the names of the topics. eg: \"test-.*-topic\", \"topic1|topic2\"")
 	ListTopicCmd.PersistentFlags().IntP("replication-factor", "r", 0, "Replication Factor of the topic")
+	ListTopicCmd.PersistentFlags().Int("min-partitions", 0, "Minimum number of partitions of the topic")
+	ListTopicCmd.PersistentFlags().Int("max-partitions", 0, "Maximum number of partitions of the topic")
+	ListTopicCmd.PersistentFlags().StringSlice("config", nil, "Config values of the topic, of the form key=value. eg: cleanup.policy=compact")
+	ListTopicCmd.PersistentFlags().Bool("internal", false, "List only the internal topics")
+	ListTopicCmd.PersistentFlags().Bool("exclude-internal", false, "Leave out the internal topics")
+	ListTopicCmd.PersistentFlags().Bool("under-replicated", false, "List only the topics with under replicated partitions")
+	ListTopicCmd.PersistentFlags().String("sort", sortByName, "Order of the topics: name, or descending partitions or replication-factor")
+	ListTopicCmd.PersistentFlags().BoolP("wide", "w", false, "Show the partitions, replication factor and key configs of the topics")
 	ListTopicCmd.PersistentFlags().Int64P("last-write", "l", 0, "Last write time for topics in epoch format")
 	ListTopicCmd.PersistentFlags().StringP("data-dir", "d", "/var/log/kafka", "Data directory for kafka logs")
+	ListTopicCmd.PersistentFlags().String("method", base.LastWriteMethodSSH, "Method to find the last write time of the topics. One of ssh, which reads the data directory on the brokers, or api, which looks up the record timestamps")
 	ListTopicCmd.PersistentFlags().StringP("ssh-port", "p", ssh_config.Default("Port"), "Ssh port on the kafka brokers")
+	ListTopicCmd.PersistentFlags().Bool("allow-partial", false, "List the stale topics from the reachable brokers when some of the brokers can not be sshed into")
 	ListTopicCmd.PersistentFlags().StringP("ssh-key-file-path", "k", "~/.ssh/id_rsa", "Path to ssh key file")
+	ListTopicCmd.PersistentFlags().String("ssh-jump-host", "", "Jump host to ssh into the brokers through, of the form [user@]host[:port]")
+	ListTopicCmd.PersistentFlags().String("ssh-known-hosts", "~/.ssh/known_hosts", "Path to the known hosts file to verify the host keys of the brokers")
+	ListTopicCmd.PersistentFlags().Bool("ssh-insecure-ignore-host-key", false, "Skip verifying the host keys of the brokers")
 }
 
 func (l *listTopic) listTopic() {
+	if err := validateSort(l.sortBy); err != nil {
+		logger.Fatal(err)
+	}
+	topicDetails, err := l.List()
+	if err != nil {
+		logger.Fatalf("Error while fetching topic list - %v\n", err)
+	}
 	if l.lastWrite != 0 {
-		err := l.listLastWrittenTopics()
-		if err != nil {
+		if topicDetails, err = l.lastWrittenTopicDetails(topicDetails); err != nil {
 			logger.Fatal(err)
 		}
-	} else {
-		topicDetails, err := l.List()
-		if err != nil {
-			logger.Fatalf("Error while fetching topic list - %v\n", err)
-		}
-		if len(topicDetails) == 0 {
-			logger.Info("No topics found.")
-			return
-		}
-		var topics []string
-		for topicDetail := range topicDetails {
-			if l.replicationFactor == 0 {
-				topics = append(topics, topicDetail)
-			} else if int(topicDetails[topicDetail].ReplicationFactor) == l.replicationFactor {
-				topics = append(topics, topicDetail)
-			}
-		}
-		printTopics(topics)
 	}
+	if len(topicDetails) == 0 {
+		logger.Info("No topics found.")
+		return
+	}
+	infos, err := l.filterTopics(topicDetails)
+	if err != nil {
+		logger.Fatalf("Error while filtering topics - %v\n", err)
+	}
+	sortTopics(infos, l.sortBy)
+	if len(infos) == 0 {
+		logger.Info("No topics found.")
+		return
+	}
+	if l.wide {
+		printWideTopics(infos)
+		return
+	}
+	printTopics(topicNames(infos))
 }
 
-func (l *listTopic) listLastWrittenTopics() error {
-	topics, err := l.ListLastWrittenTopics(l.lastWrite, l.dataDir)
+// lastWrittenTopicDetails returns the details of the topics last written before the time specified, to be filtered
+// like the rest of the topics
+func (l *listTopic) lastWrittenTopicDetails(topicDetails map[string]client.TopicDetail) (map[string]client.TopicDetail, error) {
+	var topics []string
+	var err error
+	switch l.method {
+	case base.LastWriteMethodSSH:
+		topics, err = l.ListLastWrittenTopics(l.lastWrite, l.dataDir, l.allowPartial)
+	case base.LastWriteMethodAPI:
+		topics, err = l.ListLastWrittenTopicsByTimestamp(l.lastWrite)
+	default:
+		err = fmt.Errorf("unknown method %v, should be one of ssh or api", l.method)
+	}
 	if err != nil {
 		logger.Errorf("Error while fetching topic list - %v\n", err)
-		return err
+		return nil, err
 	}
-	if len(topics) == 0 {
-		logger.Info("No topics found.")
-	} else {
-		printTopics(topics)
+
+	lastWrittenDetails := make(map[string]client.TopicDetail)
+	for _, topic := range topics {
+		if detail, ok := topicDetails[topic]; ok {
+			lastWrittenDetails[topic] = detail
+		}
 	}
-	return nil
+	return lastWrittenDetails, nil
 }
 
 func printTopics(topics []string) {
@@ -98,3 +159,15 @@ func printTopics(topics []string) {
 	}
 	fmt.Println("------------------------------------------------------------")
 }
+
+func printWideTopics(infos []topicInfo) {
+	tw := &ui.TableWriter{}
+	for _, info := range infos {
+		var configs []string
+		for _, name := range wideConfigs {
+			configs = append(configs, info.configs[name])
+		}
+		tw.AddRow(ui.TopicSummary(info.name, info.partitions, info.replicationFactor, info.isInternal, info.underReplicated, wideConfigs, configs))
+	}
+	tw.Render()
+}
@@ -5,10 +5,12 @@ import (
 	"os"
 	"testing"
 
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/pkg/client"
 
 	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/gojek/kat/logger"
 )
@@ -49,8 +51,9 @@ func TestList_Error(t *testing.T) {
 func TestListLastWritten_Success(t *testing.T) {
 	mockLister := &client.MockLister{}
 	lastWrite := int64(123123)
-	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp").Return([]string{"topic-1"}, nil).Times(1)
-	l := listTopic{Lister: mockLister, lastWrite: lastWrite, dataDir: "/tmp"}
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}, "topic-2": {}}, nil).Times(1)
+	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp", false).Return([]string{"topic-1"}, nil).Times(1)
+	l := listTopic{Lister: mockLister, lastWrite: lastWrite, method: base.LastWriteMethodSSH, dataDir: "/tmp"}
 	l.listTopic()
 	mockLister.AssertExpectations(t)
 }
@@ -58,8 +61,9 @@ func TestListLastWritten_Success(t *testing.T) {
 func TestListLastWritten_Empty(t *testing.T) {
 	mockLister := &client.MockLister{}
 	lastWrite := int64(123123)
-	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp").Return([]string{}, nil).Times(1)
-	l := listTopic{Lister: mockLister, lastWrite: lastWrite, dataDir: "/tmp"}
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}}, nil).Times(1)
+	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp", false).Return([]string{}, nil).Times(1)
+	l := listTopic{Lister: mockLister, lastWrite: lastWrite, method: base.LastWriteMethodSSH, dataDir: "/tmp"}
 	l.listTopic()
 	mockLister.AssertExpectations(t)
 }
@@ -67,13 +71,55 @@ func TestListLastWritten_Empty(t *testing.T) {
 func TestListLastWritten_Error(t *testing.T) {
 	mockLister := &client.MockLister{}
 	lastWrite := int64(123123)
-	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp").Return([]string{}, errors.New("error")).Times(1)
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}}, nil).Times(1)
+	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp", false).Return([]string{}, errors.New("error")).Times(1)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	l := listTopic{Lister: mockLister, lastWrite: lastWrite, method: base.LastWriteMethodSSH, dataDir: "/tmp"}
+	assert.PanicsWithValue(t, "os.Exit called", l.listTopic, "os.Exit was not called")
+	mockLister.AssertExpectations(t)
+}
+
+func TestListLastWritten_WithAPIMethod(t *testing.T) {
+	mockLister := &client.MockLister{}
+	lastWrite := int64(123123)
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}}, nil).Times(1)
+	mockLister.On("ListLastWrittenTopicsByTimestamp", lastWrite).Return([]string{"topic-1"}, nil).Times(1)
+	l := listTopic{Lister: mockLister, lastWrite: lastWrite, method: base.LastWriteMethodAPI}
+	l.listTopic()
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
+	mockLister.AssertExpectations(t)
+}
+
+func TestListLastWritten_WithUnknownMethod(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}}, nil)
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	l := listTopic{Lister: mockLister, lastWrite: lastWrite, dataDir: "/tmp"}
+	l := listTopic{Lister: mockLister, lastWrite: 123123, method: "jmx"}
 	assert.PanicsWithValue(t, "os.Exit called", l.listTopic, "os.Exit was not called")
+}
+
+func TestListLastWritten_AppliesFilters(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDescriber := &client.MockDescriber{}
+	lastWrite := int64(123123)
+	mockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic-1": {NumPartitions: 1}, "topic-2": {NumPartitions: 6}, "topic-3": {NumPartitions: 6}, "other": {NumPartitions: 6},
+	}, nil)
+	mockLister.On("ListLastWrittenTopics", lastWrite, "/tmp", false).Return([]string{"topic-1", "topic-2", "other"}, nil)
+	mockDescriber.On("Describe", []string{"topic-2"}).Return([]*client.TopicMetadata{{Name: "topic-2"}}, nil).Times(1)
+	l := listTopic{Lister: mockLister, Describer: mockDescriber, topics: "topic-.*", minPartitions: 2, excludeInternal: true,
+		lastWrite: lastWrite, method: base.LastWriteMethodSSH, dataDir: "/tmp"}
+
+	l.listTopic()
+
 	mockLister.AssertExpectations(t)
+	mockDescriber.AssertExpectations(t)
 }
@@ -0,0 +1,214 @@
+package list
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+const (
+	sortByName              = "name"
+	sortByPartitions        = "partitions"
+	sortByReplicationFactor = "replication-factor"
+)
+
+// wideConfigs are the configs of the topics shown in the wide mode
+var wideConfigs = []string{"cleanup.policy", "retention.ms", "min.insync.replicas"}
+
+type topicInfo struct {
+	name              string
+	partitions        int32
+	replicationFactor int16
+	isInternal        bool
+	underReplicated   int
+	configs           map[string]string
+}
+
+// filterTopics returns the topics matching all of the filters which are set, looking up the metadata and configs of
+// the topics only when they are filtered on or shown
+func (l *listTopic) filterTopics(topicDetails map[string]client.TopicDetail) ([]topicInfo, error) {
+	if l.onlyInternal && l.excludeInternal {
+		return nil, fmt.Errorf("only one of internal or exclude-internal should be passed")
+	}
+	var regex *regexp.Regexp
+	if l.topics != "" {
+		var err error
+		regex, err = regexp.Compile(l.topics)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topics regex %v - %v", l.topics, err)
+		}
+	}
+	configs, err := l.parseConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []topicInfo
+	for topic, detail := range topicDetails {
+		if l.matchDetail(regex, topic, detail) {
+			infos = append(infos, topicInfo{name: topic, partitions: detail.NumPartitions, replicationFactor: detail.ReplicationFactor})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].name < infos[j].name })
+
+	if len(infos) != 0 && (l.needsMetadata() || l.wide) {
+		if infos, err = l.withMetadata(infos); err != nil {
+			return nil, err
+		}
+	}
+	if len(infos) != 0 && (len(configs) != 0 || l.wide) {
+		if infos, err = l.withConfigs(infos, configs); err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
+
+func (l *listTopic) withMetadata(infos []topicInfo) ([]topicInfo, error) {
+	metadata, err := l.Describe(topicNames(infos))
+	if err != nil {
+		return nil, err
+	}
+	topicMetadata := make(map[string]*client.TopicMetadata)
+	for _, m := range metadata {
+		topicMetadata[m.Name] = m
+	}
+
+	var filtered []topicInfo
+	for _, info := range infos {
+		if m, ok := topicMetadata[info.name]; ok {
+			info.isInternal = m.IsInternal
+			for _, partition := range m.Partitions {
+				if len(partition.Isr) < len(partition.Replicas) {
+					info.underReplicated++
+				}
+			}
+		}
+		if l.matchMetadata(info) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered, nil
+}
+
+func (l *listTopic) withConfigs(infos []topicInfo, configs map[string]string) ([]topicInfo, error) {
+	topicConfigs, err := l.GetConfigs(topicNames(infos))
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []topicInfo
+	for _, info := range infos {
+		info.configs = make(map[string]string)
+		for _, entry := range topicConfigs[info.name] {
+			info.configs[entry.Name] = entry.Value
+		}
+		if matchConfigs(configs, info.configs) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered, nil
+}
+
+func (l *listTopic) needsMetadata() bool {
+	return l.onlyInternal || l.excludeInternal || l.underReplicated
+}
+
+// matchDetail filters on the name, replication factor and partitions, which are known from the topic list
+func (l *listTopic) matchDetail(regex *regexp.Regexp, topic string, detail client.TopicDetail) bool {
+	if regex != nil && !regex.MatchString(topic) {
+		return false
+	}
+	if l.replicationFactor != 0 && int(detail.ReplicationFactor) != l.replicationFactor {
+		return false
+	}
+	if l.minPartitions != 0 && int(detail.NumPartitions) < l.minPartitions {
+		return false
+	}
+	if l.maxPartitions != 0 && int(detail.NumPartitions) > l.maxPartitions {
+		return false
+	}
+	return true
+}
+
+// matchMetadata filters on whether the topic is internal or under replicated, which are known from its metadata
+func (l *listTopic) matchMetadata(info topicInfo) bool {
+	if l.onlyInternal && !info.isInternal {
+		return false
+	}
+	if l.excludeInternal && info.isInternal {
+		return false
+	}
+	if l.underReplicated && info.underReplicated == 0 {
+		return false
+	}
+	return true
+}
+
+// parseConfigs returns the config values to be matched, passed as key=value
+func (l *listTopic) parseConfigs() (map[string]string, error) {
+	configs := make(map[string]string)
+	for _, config := range l.configs {
+		parts := strings.SplitN(config, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid config filter %v, should be of the form key=value", config)
+		}
+		configs[parts[0]] = parts[1]
+	}
+	return configs, nil
+}
+
+// matchConfigs reports whether the topic has every config value, which can be one of the items of a list config,
+// eg: cleanup.policy=compact matches the topics with cleanup.policy=compact,delete
+func matchConfigs(configs map[string]string, topicConfigs map[string]string) bool {
+	for name, value := range configs {
+		topicValue, ok := topicConfigs[name]
+		if !ok {
+			return false
+		}
+		if topicValue == value {
+			continue
+		}
+		isItem := false
+		for _, item := range strings.Split(topicValue, ",") {
+			if strings.TrimSpace(item) == value {
+				isItem = true
+				break
+			}
+		}
+		if !isItem {
+			return false
+		}
+	}
+	return true
+}
+
+func validateSort(sortBy string) error {
+	switch sortBy {
+	case "", sortByName, sortByPartitions, sortByReplicationFactor:
+		return nil
+	}
+	return fmt.Errorf("unknown sort %v, should be one of %v, %v or %v", sortBy, sortByName, sortByPartitions, sortByReplicationFactor)
+}
+
+// sortTopics sorts the topics by name, and then in the descending order of partitions or replication factor if asked
+func sortTopics(infos []topicInfo, sortBy string) {
+	sort.Slice(infos, func(i, j int) bool { return infos[i].name < infos[j].name })
+	switch sortBy {
+	case sortByPartitions:
+		sort.SliceStable(infos, func(i, j int) bool { return infos[i].partitions > infos[j].partitions })
+	case sortByReplicationFactor:
+		sort.SliceStable(infos, func(i, j int) bool { return infos[i].replicationFactor > infos[j].replicationFactor })
+	}
+}
+
+func topicNames(infos []topicInfo) []string {
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.name)
+	}
+	return names
+}
@@ -0,0 +1,135 @@
+package list
+
+import (
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var filterTopicDetails = map[string]client.TopicDetail{
+	"topic-1":            {NumPartitions: 1, ReplicationFactor: 3},
+	"topic-2":            {NumPartitions: 12, ReplicationFactor: 2},
+	"topic-3":            {NumPartitions: 6, ReplicationFactor: 3},
+	"__consumer_offsets": {NumPartitions: 50, ReplicationFactor: 3},
+}
+
+func TestFilterTopics_ByNameAndPartitions(t *testing.T) {
+	l := listTopic{topics: "topic-.*", minPartitions: 2, maxPartitions: 12}
+
+	infos, err := l.filterTopics(filterTopicDetails)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"topic-2", "topic-3"}, topicNames(infos))
+}
+
+func TestFilterTopics_InternalAndUnderReplicated(t *testing.T) {
+	mockDescriber := &client.MockDescriber{}
+	mockDescriber.On("Describe", []string{"__consumer_offsets", "topic-1"}).Return([]*client.TopicMetadata{
+		{Name: "__consumer_offsets", IsInternal: true, Partitions: []*client.PartitionMetadata{{Replicas: []int32{1, 2, 3}, Isr: []int32{1, 2, 3}}}},
+		{Name: "topic-1", Partitions: []*client.PartitionMetadata{{Replicas: []int32{1, 2, 3}, Isr: []int32{1}}}},
+	}, nil)
+	l := listTopic{Describer: mockDescriber, replicationFactor: 3, maxPartitions: 50, minPartitions: 1}
+
+	l.topics = "^(__consumer_offsets|topic-1)$"
+	l.excludeInternal = true
+	infos, err := l.filterTopics(filterTopicDetails)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topicNames(infos))
+	assert.Equal(t, 1, infos[0].underReplicated)
+
+	l.excludeInternal = false
+	l.onlyInternal = true
+	infos, err = l.filterTopics(filterTopicDetails)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"__consumer_offsets"}, topicNames(infos))
+
+	l.onlyInternal = false
+	l.underReplicated = true
+	infos, err = l.filterTopics(filterTopicDetails)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topicNames(infos))
+}
+
+func TestFilterTopics_ByConfig(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	mockConfigurer.On("GetConfigs", []string{"topic-2"}).Return(map[string][]client.ConfigEntry{
+		"topic-2": {{Name: "cleanup.policy", Value: "compact,delete"}, {Name: "retention.ms", Value: "1000"}},
+	}, nil)
+	l := listTopic{Configurer: mockConfigurer, topics: "topic-2", configs: []string{"cleanup.policy=compact", "retention.ms=1000"}}
+
+	infos, err := l.filterTopics(filterTopicDetails)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"topic-2"}, topicNames(infos))
+
+	l.configs = []string{"cleanup.policy=delete,compact"}
+	infos, err = l.filterTopics(filterTopicDetails)
+
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+func TestFilterTopics_InvalidFilters(t *testing.T) {
+	_, err := (&listTopic{configs: []string{"cleanup.policy"}}).filterTopics(filterTopicDetails)
+	assert.EqualError(t, err, "invalid config filter cleanup.policy, should be of the form key=value")
+
+	_, err = (&listTopic{onlyInternal: true, excludeInternal: true}).filterTopics(filterTopicDetails)
+	assert.EqualError(t, err, "only one of internal or exclude-internal should be passed")
+
+	_, err = (&listTopic{topics: "("}).filterTopics(filterTopicDetails)
+	assert.Error(t, err)
+}
+
+func TestSortTopics(t *testing.T) {
+	infos := []topicInfo{{name: "b", partitions: 1, replicationFactor: 3}, {name: "c", partitions: 6, replicationFactor: 2},
+		{name: "a", partitions: 6, replicationFactor: 1}}
+
+	sortTopics(infos, sortByPartitions)
+	assert.Equal(t, []string{"a", "c", "b"}, topicNames(infos))
+
+	sortTopics(infos, sortByReplicationFactor)
+	assert.Equal(t, []string{"b", "c", "a"}, topicNames(infos))
+
+	sortTopics(infos, sortByName)
+	assert.Equal(t, []string{"a", "b", "c"}, topicNames(infos))
+
+	assert.NoError(t, validateSort(""))
+	assert.Error(t, validateSort("size"))
+}
+
+func TestList_Wide(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDescriber := &client.MockDescriber{}
+	mockConfigurer := &client.MockConfigurer{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {NumPartitions: 1, ReplicationFactor: 1}}, nil)
+	mockDescriber.On("Describe", []string{"topic-1"}).Return([]*client.TopicMetadata{{Name: "topic-1"}}, nil)
+	mockConfigurer.On("GetConfigs", []string{"topic-1"}).Return(map[string][]client.ConfigEntry{
+		"topic-1": {{Name: "cleanup.policy", Value: "delete"}},
+	}, nil)
+	l := listTopic{Lister: mockLister, Describer: mockDescriber, Configurer: mockConfigurer, sortBy: sortByName, wide: true}
+
+	l.listTopic()
+
+	mockDescriber.AssertExpectations(t)
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestList_UnknownSort(t *testing.T) {
+	mockLister := &client.MockLister{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	l := listTopic{Lister: mockLister, sortBy: "size", lastWrite: 123123, method: base.LastWriteMethodSSH}
+
+	assert.PanicsWithValue(t, "os.Exit called", l.listTopic, "os.Exit was not called")
+	mockLister.AssertNotCalled(t, "List")
+	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything, mock.Anything)
+}
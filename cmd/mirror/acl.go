@@ -0,0 +1,75 @@
+package mirror
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type aclMirror struct {
+	sourceCli      client.ACLAdmin
+	destinationCli client.ACLAdmin
+	topics         string
+	groups         string
+	dryRun         bool
+}
+
+var mirrorACLCmd = &cobra.Command{
+	Use:   "acls",
+	Short: "Mirror acls of the selected topics and consumer groups from source to destination cluster",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+
+		m := aclMirror{
+			sourceCli:      base.Init(cobraUtil, base.WithAddr("source-broker-ips")).GetACL(),
+			destinationCli: base.Init(cobraUtil, base.WithAddr("destination-broker-ips")).GetACL(),
+			topics:         cobraUtil.GetStringArg("topics"),
+			groups:         cobraUtil.GetStringArg("groups"),
+			dryRun:         cobraUtil.GetBoolArg("dry-run"),
+		}
+		m.mirrorACLs()
+	},
+}
+
+func init() {
+	mirrorACLCmd.PersistentFlags().StringP("topics", "t", "", "Regex to match the topics whose acls need to be mirrored. eg: \".*\", \"topic1|topic2\"")
+	mirrorACLCmd.PersistentFlags().StringP("groups", "g", "", "Regex to match the consumer groups whose acls need to be mirrored")
+}
+
+func (m *aclMirror) mirrorACLs() {
+	if m.topics == "" && m.groups == "" {
+		logger.Fatal("any one of topics or groups should be passed")
+	}
+
+	sourceACLs, err := m.sourceCli.ListACLs(m.topics, m.groups)
+	if err != nil {
+		logger.Fatalf("Source cluster - err while fetching acls - %v\n", err)
+	}
+
+	destinationACLs, err := m.destinationCli.ListACLs(m.topics, m.groups)
+	if err != nil {
+		logger.Fatalf("Destination cluster - err while fetching acls - %v\n", err)
+	}
+
+	tw := &ui.TableWriter{}
+	for _, acl := range model.ACLDifference(sourceACLs, destinationACLs) {
+		var err error
+		if !m.dryRun {
+			err = m.destinationCli.CreateACL(acl)
+		}
+		tw.AddRow(ui.ACLMirrorStatus(acl, true, m.dryRun, err))
+	}
+
+	for _, acl := range model.ACLDifference(destinationACLs, sourceACLs) {
+		var err error
+		if !m.dryRun {
+			err = m.destinationCli.DeleteACL(acl)
+		}
+		tw.AddRow(ui.ACLMirrorStatus(acl, false, m.dryRun, err))
+	}
+
+	tw.Render()
+}
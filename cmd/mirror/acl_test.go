@@ -0,0 +1,102 @@
+package mirror
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var topicACL = client.ACL{
+	ResourceType:   client.ACLResourceTopic,
+	ResourceName:   "topic1",
+	PatternType:    "Literal",
+	Principal:      "User:alice",
+	Host:           "*",
+	Operation:      "Read",
+	PermissionType: "Allow",
+}
+
+var groupACL = client.ACL{
+	ResourceType:   client.ACLResourceGroup,
+	ResourceName:   "group1",
+	PatternType:    "Literal",
+	Principal:      "User:alice",
+	Host:           "*",
+	Operation:      "Read",
+	PermissionType: "Allow",
+}
+
+func TestMirrorACLs_CreatesMissingAndDeletesExtraACLs(t *testing.T) {
+	sourceCli := &client.MockACLAdmin{}
+	destinationCli := &client.MockACLAdmin{}
+	sourceCli.On("ListACLs", "topic.*", "group.*").Return([]client.ACL{topicACL}, nil)
+	destinationCli.On("ListACLs", "topic.*", "group.*").Return([]client.ACL{groupACL}, nil)
+	destinationCli.On("CreateACL", topicACL).Return(nil)
+	destinationCli.On("DeleteACL", groupACL).Return(nil)
+
+	m := &aclMirror{sourceCli: sourceCli, destinationCli: destinationCli, topics: "topic.*", groups: "group.*"}
+	m.mirrorACLs()
+
+	sourceCli.AssertExpectations(t)
+	destinationCli.AssertExpectations(t)
+}
+
+func TestMirrorACLs_WhenACLsAreEqual_Noop(t *testing.T) {
+	sourceCli := &client.MockACLAdmin{}
+	destinationCli := &client.MockACLAdmin{}
+	sourceCli.On("ListACLs", "topic.*", "").Return([]client.ACL{topicACL}, nil)
+	destinationCli.On("ListACLs", "topic.*", "").Return([]client.ACL{topicACL}, nil)
+
+	m := &aclMirror{sourceCli: sourceCli, destinationCli: destinationCli, topics: "topic.*"}
+	m.mirrorACLs()
+
+	destinationCli.AssertNotCalled(t, "CreateACL", mock.Anything)
+	destinationCli.AssertNotCalled(t, "DeleteACL", mock.Anything)
+	sourceCli.AssertExpectations(t)
+	destinationCli.AssertExpectations(t)
+}
+
+func TestMirrorACLs_DryRun(t *testing.T) {
+	sourceCli := &client.MockACLAdmin{}
+	destinationCli := &client.MockACLAdmin{}
+	sourceCli.On("ListACLs", "topic.*", "group.*").Return([]client.ACL{topicACL}, nil)
+	destinationCli.On("ListACLs", "topic.*", "group.*").Return([]client.ACL{groupACL}, nil)
+
+	m := &aclMirror{sourceCli: sourceCli, destinationCli: destinationCli, topics: "topic.*", groups: "group.*", dryRun: true}
+	m.mirrorACLs()
+
+	destinationCli.AssertNotCalled(t, "CreateACL", mock.Anything)
+	destinationCli.AssertNotCalled(t, "DeleteACL", mock.Anything)
+	sourceCli.AssertExpectations(t)
+	destinationCli.AssertExpectations(t)
+}
+
+func TestMirrorACLs_SourceClusterListError(t *testing.T) {
+	sourceCli := &client.MockACLAdmin{}
+	sourceCli.On("ListACLs", "topic.*", "").Return([]client.ACL{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	m := &aclMirror{sourceCli: sourceCli, topics: "topic.*"}
+	assert.PanicsWithValue(t, "os.Exit called", m.mirrorACLs, "os.Exit was not called")
+	sourceCli.AssertExpectations(t)
+}
+
+func TestMirrorACLs_WhenNoResourceIsSelected(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	m := &aclMirror{}
+	assert.PanicsWithValue(t, "os.Exit called", m.mirrorACLs, "os.Exit was not called")
+}
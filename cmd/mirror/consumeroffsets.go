@@ -0,0 +1,207 @@
+package mirror
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type offsetCli interface {
+	client.GroupOffsetter
+	client.OffsetReader
+}
+
+type offsetClusterCli struct {
+	client.GroupOffsetter
+	client.OffsetReader
+}
+
+type offsetMirror struct {
+	sourceCli      offsetCli
+	destinationCli offsetCli
+	groups         string
+	dryRun         bool
+}
+
+type offsetTranslation struct {
+	topic        string
+	partition    int32
+	sourceOffset int64
+	oldOffset    int64
+	newOffset    int64
+	err          error
+}
+
+var mirrorConsumerOffsetsCmd = &cobra.Command{
+	Use:   "consumer-offsets",
+	Short: "Mirror committed offsets of the consumer groups from source to destination cluster",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+
+		sourceCmd := base.Init(cobraUtil, base.WithAddr("source-broker-ips"))
+		destinationCmd := base.Init(cobraUtil, base.WithAddr("destination-broker-ips"))
+		m := offsetMirror{
+			sourceCli:      offsetClusterCli{sourceCmd.GetConsumerGroup(), sourceCmd.GetTopic()},
+			destinationCli: offsetClusterCli{destinationCmd.GetConsumerGroup(), destinationCmd.GetTopic()},
+			groups:         cobraUtil.GetStringArg("groups"),
+			dryRun:         cobraUtil.GetBoolArg("dry-run"),
+		}
+		m.mirrorConsumerOffsets()
+	},
+}
+
+func init() {
+	mirrorConsumerOffsetsCmd.PersistentFlags().StringP("groups", "g", "", "Regex to match the consumer groups whose offsets need to be mirrored")
+	if err := mirrorConsumerOffsetsCmd.MarkPersistentFlagRequired("groups"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (m *offsetMirror) mirrorConsumerOffsets() {
+	groups, err := m.sourceCli.ListGroups(m.groups)
+	if err != nil {
+		logger.Fatalf("Source cluster - err while fetching consumer groups - %v\n", err)
+	}
+
+	if len(groups) == 0 {
+		logger.Infof("Did not find any consumer group matching - %v\n", m.groups)
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	for _, group := range groups {
+		m.mirrorGroupOffsets(group, tw)
+	}
+	tw.Render()
+}
+
+func (m *offsetMirror) mirrorGroupOffsets(group string, tw *ui.TableWriter) {
+	sourceOffsets, err := m.sourceCli.GetOffsets(group)
+	if err != nil {
+		logger.Errorf("Source cluster - err while fetching offsets for consumer group %v - %v\n", group, err)
+		return
+	}
+
+	destinationOffsets, err := m.destinationCli.GetOffsets(group)
+	if err != nil {
+		logger.Errorf("Destination cluster - err while fetching offsets for consumer group %v - %v\n", group, err)
+		return
+	}
+
+	var translations []offsetTranslation
+	offsetsToCommit := make(map[string]map[int32]int64)
+	for _, topic := range sortedTopics(sourceOffsets) {
+		for _, partition := range sortedPartitions(sourceOffsets[topic]) {
+			translation := offsetTranslation{topic: topic, partition: partition, sourceOffset: sourceOffsets[topic][partition], oldOffset: -1}
+			if oldOffset, ok := destinationOffsets[topic][partition]; ok {
+				translation.oldOffset = oldOffset
+			}
+			translation.newOffset, translation.err = m.translateOffset(topic, partition, translation.sourceOffset)
+			if translation.err == nil && translation.newOffset == translation.oldOffset {
+				logger.Debugf("Offsets are equal for consumer group %v on %v-%v\n", group, topic, partition)
+				continue
+			}
+			if translation.err == nil {
+				if offsetsToCommit[topic] == nil {
+					offsetsToCommit[topic] = make(map[int32]int64)
+				}
+				offsetsToCommit[topic][partition] = translation.newOffset
+			}
+			translations = append(translations, translation)
+		}
+	}
+
+	var commitErr error
+	if !m.dryRun && len(offsetsToCommit) != 0 {
+		commitErr = m.destinationCli.CommitOffsets(group, offsetsToCommit)
+		if commitErr != nil {
+			logger.Errorf("Err while committing offsets for consumer group %v in destination cluster - %v\n", group, commitErr)
+		}
+	}
+
+	for _, t := range translations {
+		err := t.err
+		if err == nil {
+			err = commitErr
+		}
+		tw.AddRow(ui.OffsetMirrorStatus(group, t.topic, t.partition, t.sourceOffset, t.oldOffset, t.newOffset, m.dryRun, err))
+	}
+}
+
+// translateOffset maps an offset of the source partition to the destination partition. The offset is retained
+// when the record at that offset has the same timestamp on both the clusters. Otherwise, the destination offset
+// is looked up by the timestamp of the source record.
+func (m *offsetMirror) translateOffset(topic string, partition int32, offset int64) (int64, error) {
+	sourceOldest, err := m.sourceCli.GetOffset(topic, partition, client.OffsetOldest)
+	if err != nil {
+		return -1, err
+	}
+	sourceNewest, err := m.sourceCli.GetOffset(topic, partition, client.OffsetNewest)
+	if err != nil {
+		return -1, err
+	}
+	destinationOldest, err := m.destinationCli.GetOffset(topic, partition, client.OffsetOldest)
+	if err != nil {
+		return -1, err
+	}
+	destinationNewest, err := m.destinationCli.GetOffset(topic, partition, client.OffsetNewest)
+	if err != nil {
+		return -1, err
+	}
+
+	if offset >= sourceNewest {
+		return destinationNewest, nil
+	}
+	if offset < sourceOldest {
+		offset = sourceOldest
+	}
+
+	timestamp, err := m.sourceCli.GetRecordTimestamp(topic, partition, offset)
+	if err != nil {
+		return -1, err
+	}
+
+	if offset >= destinationOldest && offset < destinationNewest {
+		destinationTimestamp, err := m.destinationCli.GetRecordTimestamp(topic, partition, offset)
+		if err != nil {
+			return -1, err
+		}
+		if destinationTimestamp.Equal(timestamp) {
+			return offset, nil
+		}
+	}
+
+	destinationOffset, err := m.destinationCli.GetOffset(topic, partition, timestamp.UnixNano()/int64(time.Millisecond))
+	if err != nil {
+		return -1, err
+	}
+	if destinationOffset < 0 {
+		return destinationNewest, nil
+	}
+	return destinationOffset, nil
+}
+
+func sortedTopics(offsets map[string]map[int32]int64) []string {
+	var topics []string
+	for topic := range offsets {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func sortedPartitions(offsets map[int32]int64) []int32 {
+	var partitions []int32
+	for partition := range offsets {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i] < partitions[j]
+	})
+	return partitions
+}
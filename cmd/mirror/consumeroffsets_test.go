@@ -0,0 +1,137 @@
+package mirror
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockOffsetCli struct {
+	client.MockGroupOffsetter
+	client.MockOffsetReader
+}
+
+func (m *mockOffsetCli) assertExpectations(t *testing.T) {
+	m.MockGroupOffsetter.AssertExpectations(t)
+	m.MockOffsetReader.AssertExpectations(t)
+}
+
+func (m *mockOffsetCli) onOffsetRange(topic string, partition int32, oldest, newest int64) {
+	m.MockOffsetReader.On("GetOffset", topic, partition, client.OffsetOldest).Return(oldest, nil)
+	m.MockOffsetReader.On("GetOffset", topic, partition, client.OffsetNewest).Return(newest, nil)
+}
+
+func TestMirrorConsumerOffsets_RetainsOffsetWhenRecordsLineUp(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	destinationCli := &mockOffsetCli{}
+	timestamp := time.Unix(1000, 0)
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group.*").Return([]string{"group1"}, nil)
+	sourceCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 50}}, nil)
+	destinationCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{}, nil)
+	sourceCli.onOffsetRange("topic1", 0, 0, 100)
+	destinationCli.onOffsetRange("topic1", 0, 0, 100)
+	sourceCli.MockOffsetReader.On("GetRecordTimestamp", "topic1", int32(0), int64(50)).Return(timestamp, nil)
+	destinationCli.MockOffsetReader.On("GetRecordTimestamp", "topic1", int32(0), int64(50)).Return(timestamp, nil)
+	destinationCli.MockGroupOffsetter.On("CommitOffsets", "group1", map[string]map[int32]int64{"topic1": {0: 50}}).Return(nil)
+
+	m := &offsetMirror{sourceCli: sourceCli, destinationCli: destinationCli, groups: "group.*"}
+	m.mirrorConsumerOffsets()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConsumerOffsets_TranslatesOffsetByTimestamp(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	destinationCli := &mockOffsetCli{}
+	timestamp := time.Unix(1000, 0)
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group1").Return([]string{"group1"}, nil)
+	sourceCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 50}}, nil)
+	destinationCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 5}}, nil)
+	sourceCli.onOffsetRange("topic1", 0, 0, 100)
+	destinationCli.onOffsetRange("topic1", 0, 0, 30)
+	sourceCli.MockOffsetReader.On("GetRecordTimestamp", "topic1", int32(0), int64(50)).Return(timestamp, nil)
+	destinationCli.MockOffsetReader.On("GetOffset", "topic1", int32(0), int64(1000000)).Return(int64(12), nil)
+	destinationCli.MockGroupOffsetter.On("CommitOffsets", "group1", map[string]map[int32]int64{"topic1": {0: 12}}).Return(nil)
+
+	m := &offsetMirror{sourceCli: sourceCli, destinationCli: destinationCli, groups: "group1"}
+	m.mirrorConsumerOffsets()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConsumerOffsets_MovesToLatestWhenSourceGroupIsCaughtUp(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	destinationCli := &mockOffsetCli{}
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group1").Return([]string{"group1"}, nil)
+	sourceCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 100}}, nil)
+	destinationCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{}, nil)
+	sourceCli.onOffsetRange("topic1", 0, 0, 100)
+	destinationCli.onOffsetRange("topic1", 0, 10, 80)
+	destinationCli.MockGroupOffsetter.On("CommitOffsets", "group1", map[string]map[int32]int64{"topic1": {0: 80}}).Return(nil)
+
+	m := &offsetMirror{sourceCli: sourceCli, destinationCli: destinationCli, groups: "group1"}
+	m.mirrorConsumerOffsets()
+
+	sourceCli.MockOffsetReader.AssertNotCalled(t, "GetRecordTimestamp", mock.Anything, mock.Anything, mock.Anything)
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConsumerOffsets_DryRun(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	destinationCli := &mockOffsetCli{}
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group1").Return([]string{"group1"}, nil)
+	sourceCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 100}}, nil)
+	destinationCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{}, nil)
+	sourceCli.onOffsetRange("topic1", 0, 0, 100)
+	destinationCli.onOffsetRange("topic1", 0, 0, 100)
+
+	m := &offsetMirror{sourceCli: sourceCli, destinationCli: destinationCli, groups: "group1", dryRun: true}
+	m.mirrorConsumerOffsets()
+
+	destinationCli.MockGroupOffsetter.AssertNotCalled(t, "CommitOffsets", mock.Anything, mock.Anything)
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConsumerOffsets_SkipsPartitionsThatCannotBeTranslated(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	destinationCli := &mockOffsetCli{}
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group1").Return([]string{"group1"}, nil)
+	sourceCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{"topic1": {0: 100}, "topic2": {0: 10}}, nil)
+	destinationCli.MockGroupOffsetter.On("GetOffsets", "group1").Return(map[string]map[int32]int64{}, nil)
+	sourceCli.onOffsetRange("topic1", 0, 0, 100)
+	destinationCli.onOffsetRange("topic1", 0, 0, 100)
+	sourceCli.MockOffsetReader.On("GetOffset", "topic2", int32(0), client.OffsetOldest).Return(int64(0), nil)
+	sourceCli.MockOffsetReader.On("GetOffset", "topic2", int32(0), client.OffsetNewest).Return(int64(20), nil)
+	destinationCli.MockOffsetReader.On("GetOffset", "topic2", int32(0), client.OffsetOldest).Return(int64(-1), errors.New("unknown topic"))
+	destinationCli.MockGroupOffsetter.On("CommitOffsets", "group1", map[string]map[int32]int64{"topic1": {0: 100}}).Return(nil)
+
+	m := &offsetMirror{sourceCli: sourceCli, destinationCli: destinationCli, groups: "group1"}
+	m.mirrorConsumerOffsets()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConsumerOffsets_SourceClusterListGroupsError(t *testing.T) {
+	sourceCli := &mockOffsetCli{}
+	sourceCli.MockGroupOffsetter.On("ListGroups", "group1").Return([]string{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	m := &offsetMirror{sourceCli: sourceCli, groups: "group1"}
+	assert.PanicsWithValue(t, "os.Exit called", m.mirrorConsumerOffsets, "os.Exit was not called")
+	sourceCli.assertExpectations(t)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/gojek/kat/pkg/client"
 	"github.com/gojek/kat/pkg/model"
@@ -67,6 +69,8 @@ func init() {
 	}
 	MirrorCmd.PersistentFlags().Bool("dry-run", false, "shows only the configs which gets updated")
 	MirrorCmd.PersistentFlags().StringSlice("exclude-configs", []string{}, "Comma separated list of topics configs need to be excluded")
+	MirrorCmd.AddCommand(mirrorACLCmd)
+	MirrorCmd.AddCommand(mirrorConsumerOffsetsCmd)
 }
 
 func (m *mirror) mirrorTopicConfigs() {
@@ -102,7 +106,7 @@ func (m *mirror) mirrorTopicConfigs() {
 				continue
 			} else {
 				changelogs, err := m.applyDiff(topic, sourceCM, destinationCM, sourceNumOfPartitions, destNumOfPartitions)
-				tw.AddRow(ui.MirrorStatus(topic, fmt.Sprint(changelogs), destNumOfPartitions, sourceNumOfPartitions, false, m.dryRun, err))
+				tw.AddRow(ui.MirrorStatus(topic, configChanges(changelogs), destNumOfPartitions, sourceNumOfPartitions, false, m.dryRun, err))
 			}
 		}
 	}
@@ -143,7 +147,7 @@ func (m *mirror) updateTopicInDestinationCluster(topic string, sourceNumOfPartit
 		return nil
 	}
 
-	err = m.destinationCli.UpdateConfig([]string{topic}, configToUpdate(changelogs), false)
+	err = m.destinationCli.IncrementalUpdateConfig([]string{topic}, configToUpdate(changelogs), false)
 	if err != nil {
 		logger.Errorf("Err while updating config for topic %v - %v\n", topic, err)
 	}
@@ -160,13 +164,14 @@ func getTopicDetailsAndConfigs(cli createOrUpdate) (topics map[string]client.Top
 		return nil, nil, nil
 	}
 
-	topicConfigs = make(map[string][]client.ConfigEntry)
+	var topicNames []string
 	for topic := range topics {
-		entries, err := cli.GetConfig(topic)
-		if err != nil {
-			return nil, nil, fmt.Errorf("err while reading config for topic %v - %v", topic, err)
-		}
-		topicConfigs[topic] = entries
+		topicNames = append(topicNames, topic)
+	}
+	sort.Strings(topicNames)
+	topicConfigs, err = cli.GetConfigs(topicNames)
+	if err != nil {
+		return nil, nil, fmt.Errorf("err while reading configs - %v", err)
 	}
 
 	return topics, topicConfigs, nil
@@ -186,10 +191,11 @@ func (m *mirror) increasePartitionsIfEnabled(topic string, sourceNumOfPartitions
 	return nil
 }
 
+// getConfigMap returns the configs set on the topic itself, as the broker defaults are not to be mirrored
 func getConfigMap(configList []client.ConfigEntry, excludeConfigs []string) map[string]string {
 	configMap := make(map[string]string)
 	for _, config := range configList {
-		if (model.ListUtil{List: excludeConfigs}).Contains(config.Name) {
+		if !config.IsTopicOverride() || (model.ListUtil{List: excludeConfigs}).Contains(config.Name) {
 			continue
 		}
 		configMap[config.Name] = config.Value
@@ -197,15 +203,37 @@ func getConfigMap(configList []client.ConfigEntry, excludeConfigs []string) map[
 	return configMap
 }
 
-func configToUpdate(changelogs diff.Changelog) map[string]*string {
-	result := make(map[string]*string)
+// configToUpdate reverts the configs present only in the destination cluster to their defaults
+// and sets the rest to the values in the source cluster
+func configToUpdate(changelogs diff.Changelog) map[string]client.IncrementalConfigEntry {
+	result := make(map[string]client.IncrementalConfigEntry)
 	for _, log := range changelogs {
+		if log.Type == diff.DELETE {
+			result[log.Path[0]] = client.IncrementalConfigEntry{Operation: client.ConfigDelete}
+			continue
+		}
 		val := fmt.Sprint(log.To)
-		result[log.Path[0]] = &val
+		result[log.Path[0]] = client.IncrementalConfigEntry{Operation: client.ConfigSet, Value: &val}
 	}
 	return result
 }
 
+func configChanges(changelogs diff.Changelog) string {
+	var changes []string
+	for _, log := range changelogs {
+		switch log.Type {
+		case diff.CREATE:
+			changes = append(changes, fmt.Sprintf("+ %v: %v", log.Path[0], log.To))
+		case diff.DELETE:
+			changes = append(changes, fmt.Sprintf("- %v: %v (revert to default)", log.Path[0], log.From))
+		default:
+			changes = append(changes, fmt.Sprintf("~ %v: %v -> %v", log.Path[0], log.From, log.To))
+		}
+	}
+	sort.Strings(changes)
+	return strings.Join(changes, "\n")
+}
+
 func jsonString(configs map[string]*string) string {
 	configJSON := make(map[string]string)
 	for k, v := range configs {
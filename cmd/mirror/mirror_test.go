@@ -9,6 +9,7 @@ import (
 
 	"bou.ke/monkey"
 	"github.com/gojek/kat/logger"
+	"github.com/r3labs/diff"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -60,7 +61,7 @@ func TestMirrorConfig_SourceClusterGetConfigError(t *testing.T) {
 	}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return([]client.ConfigEntry{}, errors.New("error"))
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -95,7 +96,7 @@ func TestMirrorConfig_DestinationClusterListError(t *testing.T) {
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
@@ -132,9 +133,9 @@ func TestMirrorConfig_DestinationClusterGetConfigError(t *testing.T) {
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return([]client.ConfigEntry{}, errors.New("error"))
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -177,9 +178,9 @@ func TestMirrorConfig_WhenTopicIsPresentAndConfigIsDifferent_Success(t *testing.
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -189,7 +190,8 @@ func TestMirrorConfig_WhenTopicIsPresentAndConfigIsDifferent_Success(t *testing.
 		excludeConfigs:     nil,
 	}
 	val2 := "val2"
-	destinationCli.MockConfigurer.On("UpdateConfig", []string{topicName}, map[string]*string{"key2": &val2}, false).Return(nil)
+	destinationCli.MockConfigurer.On("IncrementalUpdateConfig", []string{topicName},
+		map[string]client.IncrementalConfigEntry{"key2": {Operation: client.ConfigSet, Value: &val2}}, false).Return(nil)
 
 	m.mirrorTopicConfigs()
 
@@ -213,7 +215,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsDisabled_Noop(t *test
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -227,7 +229,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsDisabled_Noop(t *test
 	m.mirrorTopicConfigs()
 
 	destinationCli.MockCreator.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
-	destinationCli.MockConfigurer.AssertNotCalled(t, "UpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+	destinationCli.MockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
 	sourceCli.assertExpectations(t)
 	destinationCli.assertExpectations(t)
 }
@@ -248,7 +250,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_Success(t *te
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	destinationCli.MockCreator.On("Create", topicName, topic1Detail, false).Return(nil)
 	m := &mirror{
@@ -282,7 +284,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_DryRun(t *tes
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -327,9 +329,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagDisabled_Noop(t *testi
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -373,9 +375,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_Success(t *tes
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	destinationCli.MockCreator.On("CreatePartitions", topicName, topic1SrcDetail.NumPartitions, [][]int32{}, false).Return(nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -419,9 +421,9 @@ func TestMirrorConfig_WhenSourcePartitionIsLessThanDestinationPartition_Noop(t *
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -465,9 +467,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_DryRun(t *test
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -511,12 +513,13 @@ func TestMirrorConfig_WhenPartitionCountAndConfigAreDifferent_Success(t *testing
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	destinationCli.MockCreator.On("CreatePartitions", topicName, topic1SrcDetail.NumPartitions, [][]int32{}, false).Return(nil)
 	val2 := "val2"
-	destinationCli.MockConfigurer.On("UpdateConfig", []string{topicName}, map[string]*string{"key2": &val2}, false).Return(nil)
+	destinationCli.MockConfigurer.On("IncrementalUpdateConfig", []string{topicName},
+		map[string]client.IncrementalConfigEntry{"key2": {Operation: client.ConfigSet, Value: &val2}}, false).Return(nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -531,3 +534,98 @@ func TestMirrorConfig_WhenPartitionCountAndConfigAreDifferent_Success(t *testing
 	sourceCli.assertExpectations(t)
 	destinationCli.assertExpectations(t)
 }
+
+func TestMirrorConfig_WhenConfigIsPresentOnlyInDestination_RevertsToDefault(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topic1Detail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	topic1SrcConfigEntry := []client.ConfigEntry{{
+		Name:  "key1",
+		Value: "val1",
+	}}
+	topic1DestConfigEntry := []client.ConfigEntry{{
+		Name:  "key1",
+		Value: "val1",
+	}, {
+		Name:  "key2",
+		Value: "val2",
+	}}
+	topicName := "topic1"
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+	}
+	destinationCli.MockConfigurer.On("IncrementalUpdateConfig", []string{topicName},
+		map[string]client.IncrementalConfigEntry{"key2": {Operation: client.ConfigDelete}}, false).Return(nil)
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_LeavesOutBrokerConfigs(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topic1Detail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	topic1SrcConfigEntry := []client.ConfigEntry{{
+		Name:   "retention.ms",
+		Value:  "1000",
+		Source: "Topic",
+	}, {
+		Name:    "cleanup.policy",
+		Value:   "delete",
+		Default: true,
+		Source:  "Default",
+	}, {
+		Name:   "segment.bytes",
+		Value:  "100",
+		Source: "StaticBroker",
+	}}
+	topic1DestConfigEntry := []client.ConfigEntry{{
+		Name:   "retention.ms",
+		Value:  "1000",
+		Source: "Topic",
+	}, {
+		Name:   "cleanup.policy",
+		Value:  "compact",
+		Source: "Topic",
+	}, {
+		Name:    "segment.bytes",
+		Value:   "200",
+		Default: true,
+		Source:  "Default",
+	}}
+	topicName := "topic1"
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+	}
+	destinationCli.MockConfigurer.On("IncrementalUpdateConfig", []string{topicName},
+		map[string]client.IncrementalConfigEntry{"cleanup.policy": {Operation: client.ConfigDelete}}, false).Return(nil)
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestConfigChanges_ShowsDeletionsDistinctly(t *testing.T) {
+	changelogs, _ := diff.Diff(map[string]string{"key1": "val1", "key2": "val2"}, map[string]string{"key1": "val3", "key3": "val4"})
+
+	assert.Equal(t, "+ key3: val4\n- key2: val2 (revert to default)\n~ key1: val1 -> val3", configChanges(changelogs))
+}
@@ -0,0 +1,134 @@
+package produce
+
+import (
+	"io"
+	"os"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+)
+
+// recordsPerRequest is the number of records sent together and waited upon, before reading more of the input
+const recordsPerRequest = 500
+
+type produce struct {
+	client.Producer
+	topic        string
+	format       string
+	keySeparator string
+	partition    int32
+	input        io.Reader
+}
+
+var ProduceCmd = &cobra.Command{
+	Use:   "produce",
+	Short: "Produces the records read from stdin or a file to the topic",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		producerConfig := client.ProducerConfig{
+			Partitioner: cobraUtil.GetStringArg("partitioner"),
+			Acks:        cobraUtil.GetStringArg("acks"),
+			Compression: cobraUtil.GetStringArg("compression"),
+			BatchSize:   cobraUtil.GetIntArg("batch-size"),
+			BatchBytes:  cobraUtil.GetIntArg("batch-bytes"),
+			Linger:      cobraUtil.GetDurationArg("linger"),
+		}
+		input, closeInput := openInput(cobraUtil.GetStringArg("file"))
+		defer closeInput()
+
+		p := produce{
+			Producer:     base.Init(cobraUtil).GetProducer(producerConfig),
+			topic:        cobraUtil.GetStringArg("topic"),
+			format:       cobraUtil.GetStringArg("format"),
+			keySeparator: cobraUtil.GetStringArg("key-separator"),
+			partition:    int32(cobraUtil.GetIntArg("partition")),
+			input:        input,
+		}
+		p.produce()
+	},
+}
+
+func init() {
+	ProduceCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	ProduceCmd.PersistentFlags().StringP("topic", "t", "", "Topic to produce the records to")
+	ProduceCmd.PersistentFlags().StringP("file", "f", "", "File to read the records from, instead of stdin")
+	ProduceCmd.PersistentFlags().String("format", formatLine,
+		"Format of the input: line, with a record per line, json, with a json record per line, or raw, with the whole input as a record")
+	ProduceCmd.PersistentFlags().String("key-separator", "", "Separator of the key and value in a line, the lines have no key when not passed")
+	ProduceCmd.PersistentFlags().Int32("partition", -1, "Partition to produce the records without a partition to, instead of the partitioner")
+	ProduceCmd.PersistentFlags().String("partitioner", client.PartitionerHash, "Partitioner of the records: hash, of the key, random or round-robin")
+	ProduceCmd.PersistentFlags().String("acks", client.AcksAll, "Acks awaited for the records: all, 1 or 0")
+	ProduceCmd.PersistentFlags().String("compression", client.CompressionNone, "Compression of the records: none, gzip, snappy, lz4 or zstd")
+	ProduceCmd.PersistentFlags().Int("batch-size", 0, "Records buffered before a batch is sent, unbounded when 0")
+	ProduceCmd.PersistentFlags().Int("batch-bytes", 0, "Bytes buffered before a batch is sent, unbounded when 0")
+	ProduceCmd.PersistentFlags().Duration("linger", 0, "Time the records are buffered for before a batch is sent, eg: 10ms")
+	if err := ProduceCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := ProduceCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func openInput(fileName string) (io.Reader, func()) {
+	if fileName == "" {
+		return os.Stdin, func() {}
+	}
+	fileName, err := homedir.Expand(fileName)
+	if err != nil {
+		logger.Fatalf("Error while resolving file %v - %v\n", fileName, err)
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		logger.Fatalf("Error while opening file %v - %v\n", fileName, err)
+	}
+	return file, func() {
+		if err := file.Close(); err != nil {
+			logger.Errorf("Err while closing file %v - %v\n", fileName, err)
+		}
+	}
+}
+
+func (p *produce) produce() {
+	produced, err := p.produceRecords()
+	if closeErr := p.Close(); closeErr != nil {
+		logger.Errorf("Err while closing producer - %v\n", closeErr)
+	}
+	if err != nil {
+		logger.Fatalf("Error while producing records, %d records were produced - %v\n", produced, err)
+	}
+	logger.Infof("Produced %d records to topic %v\n", produced, p.topic)
+}
+
+// produceRecords reads the records and produces them in groups, returning the number of records produced
+func (p *produce) produceRecords() (int, error) {
+	reader, err := newRecordReader(p.input, p.topic, p.format, p.keySeparator, p.partition)
+	if err != nil {
+		return 0, err
+	}
+
+	produced := 0
+	var records []client.Record
+	for {
+		record, ok, err := reader.next()
+		if err != nil {
+			return produced, err
+		}
+		if ok {
+			records = append(records, record)
+		}
+		if len(records) == recordsPerRequest || (!ok && len(records) != 0) {
+			if err := p.Produce(records); err != nil {
+				return produced, err
+			}
+			produced += len(records)
+			records = nil
+		}
+		if !ok {
+			return produced, nil
+		}
+	}
+}
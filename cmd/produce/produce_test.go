@@ -0,0 +1,73 @@
+package produce
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func TestProduce_InGroups(t *testing.T) {
+	var lines []string
+	for i := 0; i < recordsPerRequest+1; i++ {
+		lines = append(lines, fmt.Sprint(i))
+	}
+	mockProducer := &client.MockProducer{}
+	mockProducer.On("Produce", mock.MatchedBy(func(records []client.Record) bool { return len(records) == recordsPerRequest })).Return(nil).Once()
+	mockProducer.On("Produce", []client.Record{{Topic: "topic-1", Partition: 1, Value: []byte(fmt.Sprint(recordsPerRequest))}}).Return(nil).Once()
+	mockProducer.On("Close").Return(nil)
+	p := produce{Producer: mockProducer, topic: "topic-1", format: formatLine, partition: 1, input: strings.NewReader(strings.Join(lines, "\n"))}
+
+	p.produce()
+
+	mockProducer.AssertExpectations(t)
+}
+
+func TestProduce_EmptyInput(t *testing.T) {
+	mockProducer := &client.MockProducer{}
+	mockProducer.On("Close").Return(nil)
+	p := produce{Producer: mockProducer, topic: "topic-1", format: formatJSON, partition: -1, input: strings.NewReader("")}
+
+	p.produce()
+
+	mockProducer.AssertNotCalled(t, "Produce", mock.Anything)
+	mockProducer.AssertExpectations(t)
+}
+
+func TestProduce_Failure(t *testing.T) {
+	mockProducer := &client.MockProducer{}
+	mockProducer.On("Produce", mock.Anything).Return(errors.New("error"))
+	mockProducer.On("Close").Return(nil)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	p := produce{Producer: mockProducer, topic: "topic-1", format: formatLine, partition: -1, input: strings.NewReader("value-1")}
+
+	assert.PanicsWithValue(t, "os.Exit called", p.produce, "os.Exit was not called")
+	mockProducer.AssertCalled(t, "Close")
+}
+
+func TestProduce_ParseFailureProducesNothing(t *testing.T) {
+	mockProducer := &client.MockProducer{}
+	mockProducer.On("Close").Return(nil)
+	p := produce{Producer: mockProducer, topic: "topic-1", format: formatJSON, partition: -1, input: strings.NewReader("{\"value\": 1}\nvalue-2")}
+
+	produced, err := p.produceRecords()
+
+	assert.Equal(t, 0, produced)
+	assert.EqualError(t, err, "err while parsing line 2 - invalid character 'v' looking for beginning of value")
+	mockProducer.AssertNotCalled(t, "Produce", mock.Anything)
+}
@@ -0,0 +1,147 @@
+package produce
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Formats of the input, line has a record per line, json has a json record per line and raw is a single record
+const (
+	formatLine = "line"
+	formatJSON = "json"
+	formatRaw  = "raw"
+)
+
+const maxLineBytes = 10 * 1024 * 1024
+
+// jsonRecord is a record in the json format, a key or value which is a json string is produced without the quotes,
+// and any other json value is produced as is
+type jsonRecord struct {
+	Key       json.RawMessage   `json:"key"`
+	Value     json.RawMessage   `json:"value"`
+	Headers   map[string]string `json:"headers"`
+	Partition *int32            `json:"partition"`
+	Timestamp *int64            `json:"timestamp"`
+}
+
+// recordReader reads the records of the topic from the input in one of the formats
+type recordReader struct {
+	topic        string
+	format       string
+	keySeparator string
+	partition    int32
+	scanner      *bufio.Scanner
+	input        io.Reader
+	line         int
+	isRawRead    bool
+}
+
+func newRecordReader(input io.Reader, topic, format, keySeparator string, partition int32) (*recordReader, error) {
+	if format != formatLine && format != formatJSON && format != formatRaw {
+		return nil, fmt.Errorf("unknown format %v, should be one of %v, %v or %v", format, formatLine, formatJSON, formatRaw)
+	}
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+	return &recordReader{topic: topic, format: format, keySeparator: keySeparator, partition: partition, scanner: scanner, input: input}, nil
+}
+
+// next returns the next record, and false when the input is over
+func (r *recordReader) next() (client.Record, bool, error) {
+	if r.format == formatRaw {
+		if r.isRawRead {
+			return client.Record{}, false, nil
+		}
+		r.isRawRead = true
+		value, err := ioutil.ReadAll(r.input)
+		if err != nil {
+			return client.Record{}, false, fmt.Errorf("err while reading input - %v", err)
+		}
+		return client.Record{Topic: r.topic, Partition: r.partition, Value: value}, true, nil
+	}
+
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSuffix(r.scanner.Text(), "\r")
+		if r.format == formatJSON && strings.TrimSpace(line) == "" {
+			continue
+		}
+		record, err := r.parse(line)
+		if err != nil {
+			return client.Record{}, false, fmt.Errorf("err while parsing line %d - %v", r.line, err)
+		}
+		return record, true, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return client.Record{}, false, fmt.Errorf("err while reading input - %v", err)
+	}
+	return client.Record{}, false, nil
+}
+
+func (r *recordReader) parse(line string) (client.Record, error) {
+	record := client.Record{Topic: r.topic, Partition: r.partition}
+	if r.format == formatLine {
+		if r.keySeparator == "" {
+			record.Value = []byte(line)
+			return record, nil
+		}
+		parts := strings.SplitN(line, r.keySeparator, 2)
+		if len(parts) != 2 {
+			return record, fmt.Errorf("key separator %q not found", r.keySeparator)
+		}
+		record.Key, record.Value = []byte(parts[0]), []byte(parts[1])
+		return record, nil
+	}
+
+	var jr jsonRecord
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&jr); err != nil {
+		return record, err
+	}
+	var err error
+	if record.Key, err = jsonBytes(jr.Key); err != nil {
+		return record, err
+	}
+	if record.Value, err = jsonBytes(jr.Value); err != nil {
+		return record, err
+	}
+	var headerKeys []string
+	for key := range jr.Headers {
+		headerKeys = append(headerKeys, key)
+	}
+	sort.Strings(headerKeys)
+	for _, key := range headerKeys {
+		record.Headers = append(record.Headers, client.RecordHeader{Key: key, Value: []byte(jr.Headers[key])})
+	}
+	if jr.Partition != nil {
+		record.Partition = *jr.Partition
+	}
+	if jr.Timestamp != nil {
+		record.Timestamp = time.Unix(0, *jr.Timestamp*int64(time.Millisecond))
+	}
+	return record, nil
+}
+
+// jsonBytes returns nil for a missing or null value, the unquoted string for a json string, and the json otherwise
+func jsonBytes(raw json.RawMessage) ([]byte, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] == '"' {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	}
+	return []byte(raw), nil
+}
@@ -0,0 +1,83 @@
+package produce
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, input, format, keySeparator string) ([]client.Record, error) {
+	reader, err := newRecordReader(strings.NewReader(input), "topic-1", format, keySeparator, -1)
+	require.NoError(t, err)
+	var records []client.Record
+	for {
+		record, ok, err := reader.next()
+		if err != nil || !ok {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestRecordReader_Line(t *testing.T) {
+	records, err := readAll(t, "value-1\r\n\nvalue-2", formatLine, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []client.Record{
+		{Topic: "topic-1", Partition: -1, Value: []byte("value-1")},
+		{Topic: "topic-1", Partition: -1, Value: []byte("")},
+		{Topic: "topic-1", Partition: -1, Value: []byte("value-2")},
+	}, records)
+}
+
+func TestRecordReader_LineWithKey(t *testing.T) {
+	records, err := readAll(t, "key-1:value:1\n", formatLine, ":")
+
+	require.NoError(t, err)
+	assert.Equal(t, []client.Record{{Topic: "topic-1", Partition: -1, Key: []byte("key-1"), Value: []byte("value:1")}}, records)
+
+	_, err = readAll(t, "key-1:value-1\nvalue-2\n", formatLine, ":")
+
+	assert.EqualError(t, err, `err while parsing line 2 - key separator ":" not found`)
+}
+
+func TestRecordReader_JSON(t *testing.T) {
+	input := `{"key": "key-1", "value": {"id": 1}, "headers": {"h2": "v2", "h1": "v1"}, "partition": 2, "timestamp": 1500000000000}
+
+{"key": "key-2", "value": null}
+`
+	records, err := readAll(t, input, formatJSON, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []client.Record{
+		{Topic: "topic-1", Partition: 2, Key: []byte("key-1"), Value: []byte(`{"id": 1}`),
+			Headers:   []client.RecordHeader{{Key: "h1", Value: []byte("v1")}, {Key: "h2", Value: []byte("v2")}},
+			Timestamp: time.Unix(1500000000, 0)},
+		{Topic: "topic-1", Partition: -1, Key: []byte("key-2")},
+	}, records)
+}
+
+func TestRecordReader_InvalidJSON(t *testing.T) {
+	_, err := readAll(t, `{"value": "value-1", "offset": 1}`, formatJSON, "")
+	assert.EqualError(t, err, `err while parsing line 1 - json: unknown field "offset"`)
+
+	_, err = readAll(t, `value-1`, formatJSON, "")
+	assert.Error(t, err)
+}
+
+func TestRecordReader_Raw(t *testing.T) {
+	records, err := readAll(t, "line-1\nline-2\n", formatRaw, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []client.Record{{Topic: "topic-1", Partition: -1, Value: []byte("line-1\nline-2\n")}}, records)
+}
+
+func TestRecordReader_UnknownFormat(t *testing.T) {
+	_, err := newRecordReader(strings.NewReader(""), "topic-1", "avro", "", -1)
+
+	assert.EqualError(t, err, "unknown format avro, should be one of line, json or raw")
+}
@@ -0,0 +1,209 @@
+package purge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type purgeCli interface {
+	client.Lister
+	client.OffsetReader
+	client.RecordDeleter
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+type purgeTopic struct {
+	purgeCli
+	topics       []string
+	beforeOffset int64
+	beforeTime   int64
+	all          bool
+	dryRun       bool
+	yes          bool
+	userInput    userInput
+}
+
+// partitionPurge is the move of the low watermark of a partition, dropping the records before the new low watermark
+type partitionPurge struct {
+	topic           string
+	partition       int32
+	lowWatermark    int64
+	newLowWatermark int64
+	highWatermark   int64
+}
+
+var PurgeTopicCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Deletes the records of the topics before an offset or a time, without deleting the topics",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		p := purgeTopic{
+			purgeCli:     base.Init(cobraUtil).GetTopic(),
+			topics:       cobraUtil.GetTopicNames(),
+			beforeOffset: int64(cobraUtil.GetIntArg("before-offset")),
+			beforeTime:   int64(cobraUtil.GetIntArg("before-time")),
+			all:          cobraUtil.GetBoolArg("all"),
+			dryRun:       cobraUtil.GetBoolArg("dry-run"),
+			yes:          cobraUtil.GetBoolArg("yes"),
+			userInput:    &ui.UserInput{},
+		}
+		p.purge()
+	},
+}
+
+func init() {
+	PurgeTopicCmd.PersistentFlags().StringP("topics", "t", "", "Comma separated list of topic names to purge")
+	PurgeTopicCmd.PersistentFlags().Int64("before-offset", -1, "Delete the records before this offset in every partition")
+	PurgeTopicCmd.PersistentFlags().Int64("before-time", 0, "Delete the records written before this epoch time in seconds")
+	PurgeTopicCmd.PersistentFlags().Bool("all", false, "Delete all the records of the topics")
+	PurgeTopicCmd.PersistentFlags().Bool("dry-run", false, "Show the records that would be deleted without deleting them")
+	PurgeTopicCmd.PersistentFlags().BoolP("yes", "y", false, "Delete the records without asking for confirmation")
+	if err := PurgeTopicCmd.MarkPersistentFlagRequired("topics"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (p *purgeTopic) purge() {
+	selected := 0
+	for _, isSelected := range []bool{p.beforeOffset >= 0, p.beforeTime > 0, p.all} {
+		if isSelected {
+			selected++
+		}
+	}
+	if selected != 1 {
+		logger.Fatal("any one of before-offset, before-time or all should be passed")
+	}
+
+	purges, err := p.partitionPurges()
+	if err != nil {
+		logger.Fatalf("Error while computing the records to be deleted - %v\n", err)
+	}
+	if len(purges) == 0 {
+		logger.Info("No records to delete")
+		return
+	}
+
+	tw := &ui.TableWriter{}
+	var dropped int64
+	for _, purge := range purges {
+		tw.AddRow(ui.PartitionPurge(purge.topic, purge.partition, purge.lowWatermark, purge.newLowWatermark, purge.highWatermark))
+		dropped += purge.newLowWatermark - purge.lowWatermark
+	}
+	tw.Render()
+
+	if p.dryRun {
+		logger.Infof("Dry run, %d records would be deleted from %d partitions\n", dropped, len(purges))
+		return
+	}
+	if !p.yes && !p.userInput.AskForConfirmation("Do you really want to delete the above records?") {
+		return
+	}
+	if err := p.deleteRecords(purges); err != nil {
+		logger.Fatalf("Error while deleting records - %v\n", err)
+	}
+	logger.Infof("Deleted %d records from %d partitions\n", dropped, len(purges))
+}
+
+// partitionPurges returns the partitions whose low watermark moves, in the order of topics and partitions
+func (p *purgeTopic) partitionPurges() ([]partitionPurge, error) {
+	topicDetails, err := p.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var purges []partitionPurge
+	for _, topic := range p.topics {
+		detail, ok := topicDetails[topic]
+		if !ok {
+			return nil, fmt.Errorf("topic %v not found in the cluster", topic)
+		}
+		for partition := int32(0); partition < detail.NumPartitions; partition++ {
+			purge, err := p.partitionPurge(topic, partition)
+			if err != nil {
+				return nil, err
+			}
+			if purge.newLowWatermark > purge.lowWatermark {
+				purges = append(purges, purge)
+			}
+		}
+	}
+	return purges, nil
+}
+
+func (p *purgeTopic) partitionPurge(topic string, partition int32) (partitionPurge, error) {
+	purge := partitionPurge{topic: topic, partition: partition}
+	var err error
+	purge.lowWatermark, err = p.GetOffset(topic, partition, client.OffsetOldest)
+	if err != nil {
+		return purge, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+	}
+	purge.highWatermark, err = p.GetOffset(topic, partition, client.OffsetNewest)
+	if err != nil {
+		return purge, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+	}
+
+	switch {
+	case p.all:
+		purge.newLowWatermark = purge.highWatermark
+	case p.beforeOffset >= 0:
+		purge.newLowWatermark = p.beforeOffset
+	default:
+		// the offset of the first record written at or after the time, none of the records are newer when there is none
+		offset, err := p.GetOffset(topic, partition, p.beforeTime*int64(time.Second/time.Millisecond))
+		if err != nil {
+			return purge, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+		}
+		if offset < 0 {
+			offset = purge.highWatermark
+		}
+		purge.newLowWatermark = offset
+	}
+
+	if purge.newLowWatermark > purge.highWatermark {
+		purge.newLowWatermark = purge.highWatermark
+	}
+	if purge.newLowWatermark < purge.lowWatermark {
+		purge.newLowWatermark = purge.lowWatermark
+	}
+	return purge, nil
+}
+
+// deleteRecords deletes the records of every topic, returning the errors of the topics that failed
+func (p *purgeTopic) deleteRecords(purges []partitionPurge) error {
+	topicOffsets := make(map[string]map[int32]int64)
+	for _, purge := range purges {
+		if topicOffsets[purge.topic] == nil {
+			topicOffsets[purge.topic] = make(map[int32]int64)
+		}
+		topicOffsets[purge.topic][purge.partition] = purge.newLowWatermark
+	}
+
+	var topics []string
+	for topic := range topicOffsets {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	var errs []string
+	for _, topic := range topics {
+		if err := p.DeleteRecords(topic, topicOffsets[topic]); err != nil {
+			logger.Errorf("Err while deleting records of topic %v - %v\n", topic, err)
+			errs = append(errs, fmt.Sprintf("%v - %v", topic, err))
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("err while deleting records: %v", strings.Join(errs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package purge
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockPurgeCli struct {
+	*client.MockLister
+	*client.MockOffsetReader
+	*client.MockRecordDeleter
+}
+
+func newMockPurgeCli() mockPurgeCli {
+	cli := mockPurgeCli{&client.MockLister{}, &client.MockOffsetReader{}, &client.MockRecordDeleter{}}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {NumPartitions: 2}, "topic-2": {NumPartitions: 1}}, nil)
+	watermarks := map[string][][2]int64{"topic-1": {{10, 100}, {0, 0}}, "topic-2": {{5, 50}}}
+	for topic, partitions := range watermarks {
+		for partition, offsets := range partitions {
+			cli.MockOffsetReader.On("GetOffset", topic, int32(partition), client.OffsetOldest).Return(offsets[0], nil)
+			cli.MockOffsetReader.On("GetOffset", topic, int32(partition), client.OffsetNewest).Return(offsets[1], nil)
+		}
+	}
+	return cli
+}
+
+func TestPurge_All(t *testing.T) {
+	cli := newMockPurgeCli()
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-1", "topic-2"}, beforeOffset: -1, all: true, yes: true}
+	cli.MockRecordDeleter.On("DeleteRecords", "topic-1", map[int32]int64{0: 100}).Return(nil)
+	cli.MockRecordDeleter.On("DeleteRecords", "topic-2", map[int32]int64{0: 50}).Return(nil)
+
+	p.purge()
+
+	cli.MockRecordDeleter.AssertExpectations(t)
+}
+
+func TestPurge_BeforeOffsetIsBoundedByTheWatermarks(t *testing.T) {
+	cli := newMockPurgeCli()
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-1", "topic-2"}, beforeOffset: 60}
+
+	purges, err := p.partitionPurges()
+
+	require.NoError(t, err)
+	assert.Equal(t, []partitionPurge{
+		{topic: "topic-1", partition: 0, lowWatermark: 10, newLowWatermark: 60, highWatermark: 100},
+		{topic: "topic-2", partition: 0, lowWatermark: 5, newLowWatermark: 50, highWatermark: 50},
+	}, purges)
+}
+
+func TestPurge_BeforeTime(t *testing.T) {
+	cli := newMockPurgeCli()
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), int64(1500000000000)).Return(int64(42), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), int64(1500000000000)).Return(int64(-1), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-2", int32(0), int64(1500000000000)).Return(int64(-1), nil)
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-1", "topic-2"}, beforeOffset: -1, beforeTime: 1500000000}
+
+	purges, err := p.partitionPurges()
+
+	require.NoError(t, err)
+	assert.Equal(t, []partitionPurge{
+		{topic: "topic-1", partition: 0, lowWatermark: 10, newLowWatermark: 42, highWatermark: 100},
+		{topic: "topic-2", partition: 0, lowWatermark: 5, newLowWatermark: 50, highWatermark: 50},
+	}, purges)
+}
+
+func TestPurge_DryRunDoesNotDelete(t *testing.T) {
+	cli := newMockPurgeCli()
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-1"}, beforeOffset: -1, all: true, dryRun: true}
+
+	p.purge()
+
+	cli.MockRecordDeleter.AssertNotCalled(t, "DeleteRecords", mock.Anything, mock.Anything)
+}
+
+func TestPurge_ExitsWhenDeleteFails(t *testing.T) {
+	cli := newMockPurgeCli()
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-1", "topic-2"}, beforeOffset: -1, all: true, yes: true}
+	cli.MockRecordDeleter.On("DeleteRecords", "topic-1", mock.Anything).Return(errors.New("not authorized"))
+	cli.MockRecordDeleter.On("DeleteRecords", "topic-2", mock.Anything).Return(nil)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", p.purge, "os.Exit was not called")
+	cli.MockRecordDeleter.AssertExpectations(t)
+}
+
+func TestPurge_ExitsUnlessOneOfTheCriteriaIsPassed(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	for _, p := range []purgeTopic{
+		{topics: []string{"topic-1"}, beforeOffset: -1},
+		{topics: []string{"topic-1"}, beforeOffset: 10, all: true},
+	} {
+		assert.PanicsWithValue(t, "os.Exit called", p.purge, "os.Exit was not called")
+	}
+}
+
+func TestPurge_WhenTopicIsNotFound(t *testing.T) {
+	cli := newMockPurgeCli()
+	p := purgeTopic{purgeCli: cli, topics: []string{"topic-3"}, beforeOffset: -1, all: true}
+
+	_, err := p.partitionPurges()
+
+	assert.EqualError(t, err, "topic topic-3 not found in the cluster")
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gojek/kat/cmd/consume"
 	"github.com/gojek/kat/cmd/mirror"
+	"github.com/gojek/kat/cmd/produce"
 
 	"github.com/gojek/kat/logger"
 	"github.com/spf13/cobra"
@@ -21,6 +23,10 @@ func init() {
 	cliCmd.AddCommand(topicCmd)
 	cliCmd.AddCommand(mirror.MirrorCmd)
 	cliCmd.AddCommand(consumerGroupCmd)
+	cliCmd.AddCommand(clusterCmd)
+	cliCmd.AddCommand(brokerCmd)
+	cliCmd.AddCommand(produce.ProduceCmd)
+	cliCmd.AddCommand(consume.ConsumeCmd)
 }
 
 func Execute() {
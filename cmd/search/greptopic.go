@@ -0,0 +1,203 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type grepCli interface {
+	client.Lister
+	client.OffsetReader
+	client.RecordConsumer
+}
+
+type grepTopic struct {
+	grepCli
+	topic      string
+	pattern    string
+	inKey      bool
+	inValue    bool
+	header     string
+	since      time.Duration
+	until      time.Duration
+	maxMatches int
+	now        func() time.Time
+	stop       <-chan struct{}
+}
+
+var GrepTopicCmd = &cobra.Command{
+	Use:   "grep",
+	Short: "Searches the records of the topic written within a time window for a pattern",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		g := grepTopic{
+			grepCli:    base.Init(cobraUtil).GetTopic(),
+			topic:      cobraUtil.GetStringArg("topic"),
+			pattern:    cobraUtil.GetStringArg("pattern"),
+			inKey:      cobraUtil.GetBoolArg("key"),
+			inValue:    cobraUtil.GetBoolArg("value"),
+			header:     cobraUtil.GetStringArg("header"),
+			since:      cobraUtil.GetDurationArg("since"),
+			until:      cobraUtil.GetDurationArg("until"),
+			maxMatches: cobraUtil.GetIntArg("max-matches"),
+			now:        time.Now,
+			stop:       base.Interrupted(),
+		}
+		g.grep()
+	},
+}
+
+func init() {
+	GrepTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to search")
+	GrepTopicCmd.PersistentFlags().StringP("pattern", "e", "", "Regex to search the records for")
+	GrepTopicCmd.PersistentFlags().Bool("key", false, "Search the keys of the records")
+	GrepTopicCmd.PersistentFlags().Bool("value", false, "Search the values of the records, which are searched when none of key, value or header are passed")
+	GrepTopicCmd.PersistentFlags().String("header", "", "Search the values of this header of the records")
+	GrepTopicCmd.PersistentFlags().Duration("since", time.Hour, "Search the records written in this duration before now, eg: 30m, 2h")
+	GrepTopicCmd.PersistentFlags().Duration("until", 0, "Leave out the records written in this duration before now, eg: 10m")
+	GrepTopicCmd.PersistentFlags().Int("max-matches", 100, "Stop after finding these many matching records")
+	if err := GrepTopicCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := GrepTopicCmd.MarkPersistentFlagRequired("pattern"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (g *grepTopic) grep() {
+	regex, err := regexp.Compile(g.pattern)
+	if err != nil {
+		logger.Fatalf("Invalid pattern %v - %v\n", g.pattern, err)
+	}
+	if g.since <= 0 || g.until < 0 || g.until >= g.since {
+		logger.Fatal("since should be positive and more than until")
+	}
+	if g.maxMatches <= 0 {
+		logger.Fatal("max-matches should be positive")
+	}
+
+	startOffsets, endOffsets, err := g.offsets()
+	if err != nil {
+		logger.Fatalf("Error while fetching offsets - %v\n", err)
+	}
+
+	scanned := 0
+	var matches []client.Record
+	err = g.Consume(g.topic, startOffsets, endOffsets, g.stop, func(record client.Record) bool {
+		scanned++
+		if g.match(regex, record) {
+			matches = append(matches, record)
+		}
+		return len(matches) < g.maxMatches
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Partition != matches[j].Partition {
+			return matches[i].Partition < matches[j].Partition
+		}
+		return matches[i].Offset < matches[j].Offset
+	})
+	tw := &ui.TableWriter{}
+	for _, record := range matches {
+		tw.AddRow(ui.RecordMatch(record.Partition, record.Offset, record.Timestamp, string(record.Key), string(record.Value), g.header, g.headerValue(regex, record)))
+	}
+	tw.Render()
+	if err != nil {
+		logger.Fatalf("Error while searching records, the search is incomplete with %d matches in %d records scanned - %v\n", len(matches), scanned, err)
+	}
+	if len(matches) == g.maxMatches {
+		logger.Infof("Stopped after %d matches in %d records scanned\n", len(matches), scanned)
+		return
+	}
+	logger.Infof("Found %d matches in %d records scanned\n", len(matches), scanned)
+}
+
+// headerValue returns the value of the searched header of the record, preferring the value matching the pattern
+// when the header is repeated
+func (g *grepTopic) headerValue(regex *regexp.Regexp, record client.Record) string {
+	value := "-"
+	for _, header := range record.Headers {
+		if header.Key != g.header {
+			continue
+		}
+		if regex.Match(header.Value) {
+			return string(header.Value)
+		}
+		if value == "-" {
+			value = string(header.Value)
+		}
+	}
+	return value
+}
+
+// match reports whether the pattern is found in any of the parts of the record being searched
+func (g *grepTopic) match(regex *regexp.Regexp, record client.Record) bool {
+	inValue := g.inValue || (!g.inKey && g.header == "")
+	if inValue && regex.Match(record.Value) {
+		return true
+	}
+	if g.inKey && regex.Match(record.Key) {
+		return true
+	}
+	if g.header != "" {
+		for _, header := range record.Headers {
+			if header.Key == g.header && regex.Match(header.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// offsets returns the offsets of the first records written since and until the time window in every partition
+func (g *grepTopic) offsets() (map[int32]int64, map[int32]int64, error) {
+	topicDetails, err := g.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	detail, ok := topicDetails[g.topic]
+	if !ok {
+		return nil, nil, fmt.Errorf("topic %v not found in the cluster", g.topic)
+	}
+
+	now := g.now()
+	startOffsets := make(map[int32]int64)
+	endOffsets := make(map[int32]int64)
+	for partition := int32(0); partition < detail.NumPartitions; partition++ {
+		high, err := g.GetOffset(g.topic, partition, client.OffsetNewest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("err while fetching offset of %v-%v - %v", g.topic, partition, err)
+		}
+		startOffsets[partition], err = g.offsetAt(partition, now.Add(-g.since), high)
+		if err != nil {
+			return nil, nil, err
+		}
+		endOffsets[partition] = high
+		if g.until > 0 {
+			if endOffsets[partition], err = g.offsetAt(partition, now.Add(-g.until), high); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return startOffsets, endOffsets, nil
+}
+
+// offsetAt returns the offset of the first record written at or after the time, or the high watermark when there is none
+func (g *grepTopic) offsetAt(partition int32, at time.Time, high int64) (int64, error) {
+	offset, err := g.GetOffset(g.topic, partition, at.UnixNano()/int64(time.Millisecond))
+	if err != nil {
+		return 0, fmt.Errorf("err while fetching offset of %v-%v - %v", g.topic, partition, err)
+	}
+	if offset < 0 {
+		return high, nil
+	}
+	return offset, nil
+}
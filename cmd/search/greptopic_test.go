@@ -0,0 +1,130 @@
+package search
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+var now = time.Unix(1500003600, 0)
+
+type mockGrepCli struct {
+	*client.MockLister
+	*client.MockOffsetReader
+	*client.MockRecordConsumer
+}
+
+func newMockGrepCli() mockGrepCli {
+	cli := mockGrepCli{&client.MockLister{}, &client.MockOffsetReader{}, &client.MockRecordConsumer{}}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {NumPartitions: 2}}, nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), client.OffsetNewest).Return(int64(100), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), client.OffsetNewest).Return(int64(50), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), int64(1500000000000)).Return(int64(40), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), int64(1500000000000)).Return(int64(-1), nil)
+	return cli
+}
+
+func newGrepTopic(cli mockGrepCli) *grepTopic {
+	return &grepTopic{grepCli: cli, topic: "topic-1", pattern: "req-[0-9]+", since: time.Hour, maxMatches: 2,
+		now: func() time.Time { return now }}
+}
+
+func TestGrep_Offsets(t *testing.T) {
+	cli := newMockGrepCli()
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(0), int64(1500003000000)).Return(int64(90), nil)
+	cli.MockOffsetReader.On("GetOffset", "topic-1", int32(1), int64(1500003000000)).Return(int64(-1), nil)
+	g := newGrepTopic(cli)
+
+	start, end, err := g.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 40, 1: 50}, start)
+	assert.Equal(t, map[int32]int64{0: 100, 1: 50}, end)
+
+	g.until = 10 * time.Minute
+	start, end, err = g.offsets()
+	require.NoError(t, err)
+	assert.Equal(t, map[int32]int64{0: 40, 1: 50}, start)
+	assert.Equal(t, map[int32]int64{0: 90, 1: 50}, end)
+}
+
+func TestGrep_StopsAfterMaxMatches(t *testing.T) {
+	cli := newMockGrepCli()
+	handled := 0
+	cli.MockRecordConsumer.On("Consume", "topic-1", map[int32]int64{0: 40, 1: 50}, map[int32]int64{0: 100, 1: 50}, mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			handle := args.Get(4).(func(client.Record) bool)
+			for _, value := range []string{"req-1", "other", "req-2", "req-3"} {
+				handled++
+				if !handle(client.Record{Value: []byte(value)}) {
+					return
+				}
+			}
+		})
+	g := newGrepTopic(cli)
+
+	g.grep()
+
+	assert.Equal(t, 3, handled)
+}
+
+func TestGrep_Match(t *testing.T) {
+	regex := regexp.MustCompile("req-1")
+	record := client.Record{Key: []byte("req-1"), Value: []byte("value"), Headers: []client.RecordHeader{{Key: "request-id", Value: []byte("req-1")}}}
+
+	assert.False(t, (&grepTopic{}).match(regex, record))
+	assert.True(t, (&grepTopic{inKey: true}).match(regex, record))
+	assert.True(t, (&grepTopic{header: "request-id"}).match(regex, record))
+	assert.False(t, (&grepTopic{header: "trace-id"}).match(regex, record))
+	assert.True(t, (&grepTopic{}).match(regex, client.Record{Value: []byte("id=req-1")}))
+	assert.False(t, (&grepTopic{inKey: true}).match(regex, client.Record{Value: []byte("req-1")}))
+}
+
+func TestGrep_HeaderValue(t *testing.T) {
+	regex := regexp.MustCompile("req-1")
+	record := client.Record{Headers: []client.RecordHeader{{Key: "request-id", Value: []byte("other")}, {Key: "request-id", Value: []byte("req-1")}}}
+
+	assert.Equal(t, "req-1", (&grepTopic{header: "request-id"}).headerValue(regex, record))
+	assert.Equal(t, "other", (&grepTopic{header: "request-id"}).headerValue(regexp.MustCompile("req-2"), record))
+	assert.Equal(t, "-", (&grepTopic{header: "trace-id"}).headerValue(regex, record))
+}
+
+func TestGrep_Failure(t *testing.T) {
+	cli := newMockGrepCli()
+	cli.MockRecordConsumer.On("Consume", "topic-1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("error")).
+		Run(client.ReplayRecords(client.Record{Value: []byte("req-1")}))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	g := newGrepTopic(cli)
+
+	assert.PanicsWithValue(t, "os.Exit called", g.grep, "os.Exit was not called")
+}
+
+func TestGrep_InvalidWindow(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	g := newGrepTopic(newMockGrepCli())
+	g.until = 2 * time.Hour
+
+	assert.PanicsWithValue(t, "os.Exit called", g.grep, "os.Exit was not called")
+}
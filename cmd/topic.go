@@ -5,7 +5,10 @@ import (
 	"github.com/gojek/kat/cmd/config"
 	"github.com/gojek/kat/cmd/delete"
 	"github.com/gojek/kat/cmd/describe"
+	"github.com/gojek/kat/cmd/disk"
 	"github.com/gojek/kat/cmd/list"
+	"github.com/gojek/kat/cmd/purge"
+	"github.com/gojek/kat/cmd/search"
 	"github.com/gojek/kat/logger"
 	"github.com/spf13/cobra"
 )
@@ -23,9 +26,14 @@ func init() {
 
 	topicCmd.AddCommand(list.ListTopicCmd)
 	topicCmd.AddCommand(delete.DeleteTopicCmd)
+	topicCmd.AddCommand(delete.RestoreTopicCmd)
 	topicCmd.AddCommand(describe.DescribeTopicCmd)
 	topicCmd.AddCommand(admin.IncreaseReplicationFactorCmd)
 	topicCmd.AddCommand(admin.ReassignPartitionsCmd)
+	topicCmd.AddCommand(admin.AddPartitionsCmd)
 	topicCmd.AddCommand(config.ConfigCmd)
+	topicCmd.AddCommand(disk.TopicSizeCmd)
+	topicCmd.AddCommand(purge.PurgeTopicCmd)
+	topicCmd.AddCommand(search.GrepTopicCmd)
 
 }
@@ -4,19 +4,19 @@ go 1.12
 
 require (
 	bou.ke/monkey v1.0.2
-	github.com/Shopify/sarama v1.24.1
+	github.com/Shopify/sarama v1.31.1
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/kevinburke/ssh_config v0.0.0-20190725054713-01f96b0aa0cd
-	github.com/klauspost/cpuid v1.2.2 // indirect
 	github.com/mattn/go-runewidth v0.0.5 // indirect
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/olekukonko/tablewriter v0.0.1
 	github.com/pkg/errors v0.9.1
 	github.com/r3labs/diff v0.0.0-20191018104334-e3ae93f4edbb
-	github.com/sirupsen/logrus v1.4.2
+	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v0.0.3
 	github.com/spf13/pflag v1.0.3 // indirect
-	github.com/stretchr/testify v1.3.0
-	golang.org/x/crypto v0.0.0-20191029031824-8986dd9e96cf
-	golang.org/x/net v0.0.0-20191028085509-fe3aa8a45271 // indirect
+	github.com/stretchr/objx v0.1.1 // indirect
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/crypto v0.0.0-20220128200615-198e4374d7ed
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -1,5 +1,11 @@
 package client
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type TopicDetail struct {
 	NumPartitions     int32
 	ReplicationFactor int16
@@ -39,27 +45,193 @@ type ConfigEntry struct {
 	Synonyms  []*ConfigSynonym
 }
 
+// IsOverridden reports whether the config is set on the topic or dynamically on the brokers, instead of being
+// a static broker config or a default
+func (c ConfigEntry) IsOverridden() bool {
+	switch c.Source {
+	case "", "Unknown":
+		return !c.Default
+	case "Topic", "DynamicBroker", "DynamicDefaultBroker":
+		return true
+	}
+	return false
+}
+
+// IsTopicOverride reports whether the config is set on the topic itself, leaving out the broker configs which
+// apply to the topic without being pinned on it
+func (c ConfigEntry) IsTopicOverride() bool {
+	switch c.Source {
+	case "", "Unknown":
+		return !c.Default
+	case "Topic":
+		return true
+	}
+	return false
+}
+
 type ConfigSynonym struct {
 	ConfigName  string
 	ConfigValue string
 	Source      string
 }
 
+type ConfigOperation int
+
+const (
+	ConfigSet ConfigOperation = iota
+	ConfigDelete
+	ConfigAppend
+	ConfigSubtract
+)
+
+type IncrementalConfigEntry struct {
+	Operation ConfigOperation
+	Value     *string
+}
+
+// Apply returns the value of the config after the operation, or nil when the override is removed. Append and
+// subtract operate on the comma separated values of list configs, starting from the current or default value.
+func (e IncrementalConfigEntry) Apply(current string) *string {
+	switch e.Operation {
+	case ConfigSet:
+		return e.Value
+	case ConfigAppend, ConfigSubtract:
+		value := alterListConfig(current, *e.Value, e.Operation == ConfigAppend)
+		return &value
+	}
+	return nil
+}
+
+func alterListConfig(current, values string, isAppend bool) string {
+	var list []string
+	present := make(map[string]bool)
+	for _, value := range strings.Split(current, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			list = append(list, value)
+			present[value] = true
+		}
+	}
+
+	altered := make(map[string]bool)
+	for _, value := range strings.Split(values, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		altered[value] = true
+		if isAppend && !present[value] {
+			list = append(list, value)
+			present[value] = true
+		}
+	}
+
+	if isAppend {
+		return strings.Join(list, ",")
+	}
+
+	var remaining []string
+	for _, value := range list {
+		if !altered[value] {
+			remaining = append(remaining, value)
+		}
+	}
+	return strings.Join(remaining, ",")
+}
+
+type ACL struct {
+	ResourceType   string `json:"resourceType"`
+	ResourceName   string `json:"resourceName"`
+	PatternType    string `json:"patternType"`
+	Principal      string `json:"principal"`
+	Host           string `json:"host"`
+	Operation      string `json:"operation"`
+	PermissionType string `json:"permissionType"`
+}
+
+func (a ACL) Resource() string {
+	return fmt.Sprintf("%s:%s (%s)", a.ResourceType, a.ResourceName, a.PatternType)
+}
+
+func (a ACL) Binding() string {
+	return fmt.Sprintf("%s %s %s from %s", a.Principal, a.PermissionType, a.Operation, a.Host)
+}
+
+const (
+	OffsetNewest int64 = -1
+	OffsetOldest int64 = -2
+)
+
+const (
+	ACLResourceTopic = "Topic"
+	ACLResourceGroup = "Group"
+)
+
+// LogDir is a log directory of a broker along with the replicas stored in it
+type LogDir struct {
+	Broker   int32
+	Path     string
+	Err      error
+	Replicas []ReplicaLog
+}
+
+type ReplicaLog struct {
+	Topic       string
+	Partition   int32
+	Size        int64
+	OffsetLag   int64
+	IsTemporary bool
+}
+
+type Record struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []RecordHeader
+	Timestamp time.Time
+}
+
+type RecordHeader struct {
+	Key   string
+	Value []byte
+}
+
 type ListTopicsRequest struct {
-	LastWritten int64
-	DataDir     string
+	LastWritten  int64
+	DataDir      string
+	AllowPartial bool
 }
 
 type KafkaAPIClient interface {
 	CreateTopic(topic string, detail TopicDetail, validateOnly bool) error
 	CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error
 	ListBrokers() map[int]string
+	ListBrokerRacks() map[int32]string
 	ListTopicDetails() (map[string]TopicDetail, error)
 	DeleteTopic(topics []string) error
+	DeleteRecords(topic string, partitionOffsets map[int32]int64) error
 	DescribeTopicMetadata(topics []string) ([]*TopicMetadata, error)
 	UpdateConfig(resourceType int, name string, entries map[string]*string, validateOnly bool) error
+	IncrementalUpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error
 	GetTopicResourceType() int
+	GetBrokerResourceType() int
 	GetConfig(resource ConfigResource) ([]ConfigEntry, error)
+	GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error)
+	DescribeLogDirs(brokerIDs []int32) ([]LogDir, error)
+	ListACLs() ([]ACL, error)
+	CreateACL(acl ACL) error
+	DeleteACL(acl ACL) error
+	ListConsumerGroups() (map[string]string, error)
+	GetConsumerGroupsForTopic(groups []string, topic string) (chan string, error)
+	GetConsumerGroupsForTopics(groups []string, topics []string) (map[string][]string, error)
+	GetConsumerGroupOffsets(group string) (map[string]map[int32]int64, error)
+	CommitConsumerGroupOffsets(group string, offsets map[string]map[int32]int64) error
+	GetOffset(topic string, partition int32, timestamp int64) (int64, error)
+	GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error)
+	GetRecord(topic string, partition int32, offset int64) (Record, error)
+	Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(Record) bool) error
 }
 
 type KafkaSSHClient interface {
@@ -2,8 +2,11 @@ package client
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gojek/kat/pkg/io"
 
@@ -18,6 +21,14 @@ type sshCli interface {
 	DialAndExecute(address string, commands ...shellCmd) (*bytes.Buffer, error)
 }
 
+const sshConcurrency = 10
+
+type brokerPartitions struct {
+	id         int32
+	partitions map[string][]int32
+	err        error
+}
+
 type kafkaRemoteClient struct {
 	KafkaAPIClient
 	sshCli
@@ -29,44 +40,96 @@ func NewKafkaRemoteClient(apiClient KafkaAPIClient, sshClient sshCli) (KafkaSSHC
 
 func (r *kafkaRemoteClient) ListTopics(request ListTopicsRequest) ([]string, error) {
 	brokers := r.ListBrokers()
-	topicMap := make(map[string]int)
-	for id := 1; id <= len(brokers); id++ {
-		logger.Infof("Sshing into broker - %v\n", brokers[id])
-		data, err := r.sshCli.DialAndExecute(strings.Split(brokers[id], ":")[0], io.NewCdCmd(request.DataDir),
-			io.NewFindTopicsCmd(request.LastWritten, request.DataDir))
-		if err != nil {
-			logger.Errorf("Error while executing command on broker - %v\n", err)
-			return nil, err
+	// brokers on which each partition of a topic is last written before the time specified
+	staleReplicas := make(map[string]map[int32]map[int32]bool)
+	failedBrokers := make(map[int32]bool)
+	var errs []string
+	for _, result := range r.listBrokerPartitions(brokers, request) {
+		if result.err != nil {
+			logger.Errorf("Error while listing topics in broker %v - %v\n", result.id, result.err)
+			failedBrokers[result.id] = true
+			errs = append(errs, fmt.Sprintf("broker %v - %v", result.id, result.err))
+			continue
 		}
-		logger.Info("Fetching the stale topics")
-		topics := strings.Split(data.String(), "\n")
-		err = r.mapTopics(topicMap, topics)
-		if err != nil {
-			logger.Errorf("Error while reading topics in broker %v - %v\n", id, err)
-			return nil, err
+		for topic, partitions := range result.partitions {
+			if staleReplicas[topic] == nil {
+				staleReplicas[topic] = make(map[int32]map[int32]bool)
+			}
+			for _, partition := range partitions {
+				if staleReplicas[topic][partition] == nil {
+					staleReplicas[topic][partition] = make(map[int32]bool)
+				}
+				staleReplicas[topic][partition][result.id] = true
+			}
 		}
 	}
 
-	topics, e := r.getFullyStaleTopics(topicMap)
+	if len(errs) != 0 && (!request.AllowPartial || len(errs) == len(brokers)) {
+		return nil, fmt.Errorf("err while listing topics in brokers: %v", strings.Join(errs, ", "))
+	}
+	if len(errs) != 0 {
+		logger.Warnf("Skipping the unreachable brokers, the topics with replicas on them are checked on the other replicas: %v\n", strings.Join(errs, ", "))
+	}
+
+	logger.Info("Fetching the stale topics")
+	topics, e := r.getFullyStaleTopics(staleReplicas, failedBrokers)
 	return topics, e
 }
 
-func (r *kafkaRemoteClient) mapTopics(topicMap map[string]int, topics []string) error {
-	for _, topic := range topics {
-		if topic == "" {
+// listBrokerPartitions runs the find command on the brokers in parallel, sshing into at most sshConcurrency brokers at a time
+func (r *kafkaRemoteClient) listBrokerPartitions(brokers map[int]string, request ListTopicsRequest) []brokerPartitions {
+	ids := make(chan int, len(brokers))
+	for id := range brokers {
+		ids <- id
+	}
+	close(ids)
+
+	results := make(chan brokerPartitions, len(brokers))
+	var wg sync.WaitGroup
+	for i := 0; i < sshConcurrency && i < len(brokers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				partitions, err := r.listPartitionsInBroker(brokers[id], request)
+				results <- brokerPartitions{id: int32(id), partitions: partitions, err: err}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var brokerResults []brokerPartitions
+	for result := range results {
+		brokerResults = append(brokerResults, result)
+	}
+	sort.Slice(brokerResults, func(i, j int) bool {
+		return brokerResults[i].id < brokerResults[j].id
+	})
+	return brokerResults
+}
+
+func (r *kafkaRemoteClient) listPartitionsInBroker(broker string, request ListTopicsRequest) (map[string][]int32, error) {
+	logger.Infof("Sshing into broker - %v\n", broker)
+	data, err := r.sshCli.DialAndExecute(strings.Split(broker, ":")[0], io.NewCdCmd(request.DataDir),
+		io.NewFindTopicsCmd(request.LastWritten, request.DataDir))
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make(map[string][]int32)
+	for _, dir := range strings.Split(data.String(), "\n") {
+		topic, partition, ok := parsePartitionDir(dir)
+		if !ok {
+			logger.Debugf("Skipping %v in broker %v as it is not a partition directory\n", dir, broker)
 			continue
 		}
-		detail := strings.Split(topic, " ")
-		i, err := strconv.Atoi(detail[1])
-		if err != nil {
-			return err
-		}
-		topicMap[detail[0]] += i
+		partitions[topic] = append(partitions[topic], partition)
 	}
-	return nil
+	return partitions, nil
 }
 
-func (r *kafkaRemoteClient) getFullyStaleTopics(topicMap map[string]int) ([]string, error) {
+func (r *kafkaRemoteClient) getFullyStaleTopics(staleReplicas map[string]map[int32]map[int32]bool, failedBrokers map[int32]bool) ([]string, error) {
 	var staleTopics []string
 	topicDetails, err := r.ListTopicDetails()
 	if err != nil {
@@ -74,19 +137,63 @@ func (r *kafkaRemoteClient) getFullyStaleTopics(topicMap map[string]int) ([]stri
 		return nil, err
 	}
 
-	for topic := range topicMap {
+	for topic := range staleReplicas {
 		detail, ok := topicDetails[topic]
 		if !ok {
-			logger.Debugf("topic cannot be processed as it is not returned by the list api %v - %v\n", topic, err)
+			logger.Debugf("topic cannot be processed as it is not returned by the list api %v\n", topic)
 			continue
 		}
 
 		// Mark a topic unused, only if all the partitions are last written before the time specified
-		if topicMap[topic] != (int(detail.NumPartitions) * int(detail.ReplicationFactor)) {
+		if !isFullyStale(topic, detail, staleReplicas[topic], failedBrokers) {
 			continue
 		}
 
 		staleTopics = append(staleTopics, topic)
 	}
+	sort.Strings(staleTopics)
 	return staleTopics, nil
 }
+
+// isFullyStale checks that every replica of every partition in the metadata, leaving out the ones on the failed brokers,
+// is last written before the time specified. A topic with a partition having all of its replicas on the failed brokers
+// is left out, as the partition cannot be verified.
+func isFullyStale(topic string, detail TopicDetail, staleReplicas map[int32]map[int32]bool, failedBrokers map[int32]bool) bool {
+	var unverified []int32
+	for partition, replicas := range detail.ReplicaAssignment {
+		checked := 0
+		for _, replica := range replicas {
+			if failedBrokers[replica] {
+				continue
+			}
+			if !staleReplicas[partition][replica] {
+				logger.Debugf("%v-%v is written after the time specified in broker %v\n", topic, partition, replica)
+				return false
+			}
+			checked++
+		}
+		if checked == 0 {
+			unverified = append(unverified, partition)
+		}
+	}
+	if len(unverified) != 0 {
+		sort.Slice(unverified, func(i, j int) bool { return unverified[i] < unverified[j] })
+		logger.Warnf("Skipping topic %v as it is unverified, the partitions %v have all of their replicas on the unreachable brokers\n",
+			topic, unverified)
+		return false
+	}
+	return len(detail.ReplicaAssignment) != 0
+}
+
+// parsePartitionDir splits a partition directory like topic-0 into the topic and the partition
+func parsePartitionDir(dir string) (string, int32, bool) {
+	i := strings.LastIndex(dir, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	partition, err := strconv.ParseInt(dir[i+1:], 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return dir[:i], int32(partition), true
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"bytes"
 	"errors"
-	"sort"
 	"testing"
 
 	"github.com/gojek/kat/pkg/io"
@@ -16,6 +15,16 @@ func init() {
 	logger.SetupLogger("info")
 }
 
+func findTopicsCmds(request ListTopicsRequest) []shellCmd {
+	return []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}
+}
+
+func partitionDirs(dirs string) *bytes.Buffer {
+	response := bytes.Buffer{}
+	response.WriteString(dirs)
+	return &response
+}
+
 func TestKafkaRemoteClient_ListTopics_AllPartitionsStale(t *testing.T) {
 	apiClient := &MockKafkaAPIClient{}
 	sshCli := &MockSSHCli{}
@@ -27,27 +36,24 @@ func TestKafkaRemoteClient_ListTopics_AllPartitionsStale(t *testing.T) {
 
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	broker1Response.WriteString("topic-1 1\ntopic-2 1")
-	broker2Response := bytes.Buffer{}
-	broker2Response.WriteString("topic-1 1\ntopic-2 1")
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, nil)
-	sshCli.On("DialAndExecute", "broker-2", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker2Response, nil)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
 	expectedTopicDetails := map[string]TopicDetail{
 		"topic-1": {
 			NumPartitions:     1,
 			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}},
 		},
 		"topic-2": {
 			NumPartitions:     1,
 			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {2, 1}},
 		},
 	}
 	apiClient.On("ListTopicDetails").Return(expectedTopicDetails, nil)
 
 	topics, err := remoteClient.ListTopics(request)
 	assert.NoError(t, err)
-	sort.Strings(topics)
 	assert.Equal(t, []string{"topic-1", "topic-2"}, topics)
 	apiClient.AssertExpectations(t)
 	sshCli.AssertExpectations(t)
@@ -64,20 +70,18 @@ func TestKafkaRemoteClient_ListTopics_SomePartitionsAreStale(t *testing.T) {
 
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	broker1Response.WriteString("topic-1 1\ntopic-2 1")
-	broker2Response := bytes.Buffer{}
-	broker2Response.WriteString("topic-1 1")
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, nil)
-	sshCli.On("DialAndExecute", "broker-2", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker2Response, nil)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\n"), nil)
 	expectedTopicDetails := map[string]TopicDetail{
 		"topic-1": {
 			NumPartitions:     1,
 			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}},
 		},
 		"topic-2": {
 			NumPartitions:     1,
 			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}},
 		},
 	}
 	apiClient.On("ListTopicDetails").Return(expectedTopicDetails, nil)
@@ -89,6 +93,70 @@ func TestKafkaRemoteClient_ListTopics_SomePartitionsAreStale(t *testing.T) {
 	sshCli.AssertExpectations(t)
 }
 
+func TestKafkaRemoteClient_ListTopics_BrokerIDsAreNotSequential(t *testing.T) {
+	apiClient := &MockKafkaAPIClient{}
+	sshCli := &MockSSHCli{}
+	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
+	request := ListTopicsRequest{
+		LastWritten: 123,
+		DataDir:     "/tmp",
+	}
+
+	brokers := map[int]string{101: "broker-101:80", 102: "broker-102:80", 205: "broker-205:80"}
+	apiClient.On("ListBrokers").Return(brokers)
+	sshCli.On("DialAndExecute", "broker-101", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-102", findTopicsCmds(request)).Return(partitionDirs("topic-1-1\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-205", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-1-1\n"), nil)
+	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{
+		"topic-1": {
+			NumPartitions:     2,
+			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {101, 205}, 1: {205, 102}},
+		},
+		"topic-2": {
+			NumPartitions:     1,
+			ReplicationFactor: 3,
+			ReplicaAssignment: map[int32][]int32{0: {101, 102, 205}},
+		},
+	}, nil)
+
+	topics, err := remoteClient.ListTopics(request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topics)
+	apiClient.AssertExpectations(t)
+	sshCli.AssertExpectations(t)
+}
+
+func TestKafkaRemoteClient_ListTopics_ReplicasOnDiskDoNotMatchMetadata(t *testing.T) {
+	apiClient := &MockKafkaAPIClient{}
+	sshCli := &MockSSHCli{}
+	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
+	request := ListTopicsRequest{
+		LastWritten: 123,
+		DataDir:     "/tmp",
+	}
+
+	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80", 3: "broker-3:80"}
+	apiClient.On("ListBrokers").Return(brokers)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-1-1\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\n"), nil)
+	// a leftover replica of partition 1 after it was moved from broker 3 to broker 2
+	sshCli.On("DialAndExecute", "broker-3", findTopicsCmds(request)).Return(partitionDirs("topic-1-1\n"), nil)
+	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{
+		"topic-1": {
+			NumPartitions:     2,
+			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {1, 2}},
+		},
+	}, nil)
+
+	topics, err := remoteClient.ListTopics(request)
+	assert.NoError(t, err)
+	assert.Empty(t, topics)
+	apiClient.AssertExpectations(t)
+	sshCli.AssertExpectations(t)
+}
+
 func TestKafkaRemoteClient_ListTopics_ApiClientDoesNotReturnTopicDetail(t *testing.T) {
 	apiClient := &MockKafkaAPIClient{}
 	sshCli := &MockSSHCli{}
@@ -100,16 +168,13 @@ func TestKafkaRemoteClient_ListTopics_ApiClientDoesNotReturnTopicDetail(t *testi
 
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	broker1Response.WriteString("topic-1 1\ntopic-2 1")
-	broker2Response := bytes.Buffer{}
-	broker2Response.WriteString("topic-1 1\ntopic-2 1")
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, nil)
-	sshCli.On("DialAndExecute", "broker-2", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker2Response, nil)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
 	expectedTopicDetails := map[string]TopicDetail{
 		"topic-1": {
 			NumPartitions:     1,
 			ReplicationFactor: 2,
+			ReplicaAssignment: map[int32][]int32{0: {1, 2}},
 		},
 	}
 	apiClient.On("ListTopicDetails").Return(expectedTopicDetails, nil)
@@ -132,12 +197,8 @@ func TestKafkaRemoteClient_ListTopics_ApiClientReturnsError(t *testing.T) {
 
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	broker1Response.WriteString("topic-1 1\ntopic-2 1")
-	broker2Response := bytes.Buffer{}
-	broker2Response.WriteString("topic-1 1\ntopic-2 1")
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, nil)
-	sshCli.On("DialAndExecute", "broker-2", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker2Response, nil)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
 	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{}, errors.New("error"))
 
 	topics, err := remoteClient.ListTopics(request)
@@ -158,18 +219,18 @@ func TestKafkaRemoteClient_ListTopics_DialAndExecuteReturnsError(t *testing.T) {
 
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, errors.New("error"))
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(&bytes.Buffer{}, errors.New("error"))
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\n"), nil)
 
 	topics, err := remoteClient.ListTopics(request)
-	assert.Error(t, err)
+	assert.EqualError(t, err, "err while listing topics in brokers: broker 1 - error")
 	assert.Nil(t, topics)
 	apiClient.AssertNotCalled(t, "ListTopicDetails")
 	apiClient.AssertExpectations(t)
 	sshCli.AssertExpectations(t)
 }
 
-func TestKafkaRemoteClient_ListTopics_DataIsNotReturnedInExpectedFormat(t *testing.T) {
+func TestKafkaRemoteClient_ListTopics_SkipsEntriesThatAreNotPartitions(t *testing.T) {
 	apiClient := &MockKafkaAPIClient{}
 	sshCli := &MockSSHCli{}
 	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
@@ -178,16 +239,94 @@ func TestKafkaRemoteClient_ListTopics_DataIsNotReturnedInExpectedFormat(t *testi
 		DataDir:     "/tmp",
 	}
 
+	brokers := map[int]string{1: "broker-1:80"}
+	apiClient.On("ListBrokers").Return(brokers)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("cleaner-offset-checkpoint\nmeta.properties\ntopic-1-0\ntopic-2-0.5e6a2b-delete\n"), nil)
+	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{
+		"topic-1": {NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}},
+		"topic-2": {NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}},
+	}, nil)
+
+	topics, err := remoteClient.ListTopics(request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topics)
+	apiClient.AssertExpectations(t)
+	sshCli.AssertExpectations(t)
+}
+
+func TestKafkaRemoteClient_ListTopics_AllowPartialSkipsUnreachableBrokers(t *testing.T) {
+	apiClient := &MockKafkaAPIClient{}
+	sshCli := &MockSSHCli{}
+	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
+	request := ListTopicsRequest{
+		LastWritten:  123,
+		DataDir:      "/tmp",
+		AllowPartial: true,
+	}
+
+	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80", 3: "broker-3:80"}
+	apiClient.On("ListBrokers").Return(brokers)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-3", findTopicsCmds(request)).Return(&bytes.Buffer{}, errors.New("connection refused"))
+	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{
+		"topic-1": {NumPartitions: 1, ReplicationFactor: 3, ReplicaAssignment: map[int32][]int32{0: {1, 2, 3}}},
+		"topic-2": {NumPartitions: 1, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}}},
+		"topic-3": {NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {3}}},
+	}, nil)
+
+	topics, err := remoteClient.ListTopics(request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topics)
+	apiClient.AssertExpectations(t)
+	sshCli.AssertExpectations(t)
+}
+
+func TestKafkaRemoteClient_ListTopics_AllowPartialSkipsTopicsWithUnverifiedPartitions(t *testing.T) {
+	apiClient := &MockKafkaAPIClient{}
+	sshCli := &MockSSHCli{}
+	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
+	request := ListTopicsRequest{
+		LastWritten:  123,
+		DataDir:      "/tmp",
+		AllowPartial: true,
+	}
+
+	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80", 3: "broker-3:80"}
+	apiClient.On("ListBrokers").Return(brokers)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(partitionDirs("topic-1-0\ntopic-2-0\n"), nil)
+	sshCli.On("DialAndExecute", "broker-3", findTopicsCmds(request)).Return(&bytes.Buffer{}, errors.New("connection refused"))
+	apiClient.On("ListTopicDetails").Return(map[string]TopicDetail{
+		"topic-1": {NumPartitions: 1, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}}},
+		"topic-2": {NumPartitions: 2, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}, 1: {3}}},
+	}, nil)
+
+	topics, err := remoteClient.ListTopics(request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"topic-1"}, topics)
+	apiClient.AssertExpectations(t)
+	sshCli.AssertExpectations(t)
+}
+
+func TestKafkaRemoteClient_ListTopics_AllowPartialWhenAllBrokersAreUnreachable(t *testing.T) {
+	apiClient := &MockKafkaAPIClient{}
+	sshCli := &MockSSHCli{}
+	remoteClient, _ := NewKafkaRemoteClient(apiClient, sshCli)
+	request := ListTopicsRequest{
+		LastWritten:  123,
+		DataDir:      "/tmp",
+		AllowPartial: true,
+	}
+
 	brokers := map[int]string{1: "broker-1:80", 2: "broker-2:80"}
 	apiClient.On("ListBrokers").Return(brokers)
-	broker1Response := bytes.Buffer{}
-	broker1Response.WriteString("topic-1 1\ntopic-2 abc")
-	sshCli.On("DialAndExecute", "broker-1", []shellCmd{io.NewCdCmd(request.DataDir), io.NewFindTopicsCmd(request.LastWritten, request.DataDir)}).Return(&broker1Response, nil)
+	sshCli.On("DialAndExecute", "broker-1", findTopicsCmds(request)).Return(&bytes.Buffer{}, errors.New("connection refused"))
+	sshCli.On("DialAndExecute", "broker-2", findTopicsCmds(request)).Return(&bytes.Buffer{}, errors.New("connection refused"))
 
 	topics, err := remoteClient.ListTopics(request)
-	assert.Error(t, err)
+	assert.EqualError(t, err, "err while listing topics in brokers: broker 1 - connection refused, broker 2 - connection refused")
 	assert.Nil(t, topics)
 	apiClient.AssertNotCalled(t, "ListTopicDetails")
-	apiClient.AssertExpectations(t)
 	sshCli.AssertExpectations(t)
 }
@@ -1,5 +1,7 @@
 package client
 
+import "time"
+
 type Creator interface {
 	Create(topic string, detail TopicDetail, validateOnly bool) error
 	CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error
@@ -7,7 +9,8 @@ type Creator interface {
 
 type Lister interface {
 	List() (map[string]TopicDetail, error)
-	ListLastWrittenTopics(int64, string) ([]string, error)
+	ListLastWrittenTopics(int64, string, bool) ([]string, error)
+	ListLastWrittenTopicsByTimestamp(int64) ([]string, error)
 	ListOnly(regex string, include bool) ([]string, error)
 }
 
@@ -17,14 +20,63 @@ type Describer interface {
 
 type Configurer interface {
 	GetConfig(topic string) ([]ConfigEntry, error)
+	GetConfigs(topics []string) (map[string][]ConfigEntry, error)
 	UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error
+	IncrementalUpdateConfig(topics []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error
+}
+
+type BrokerConfigurer interface {
+	ListBrokerIDs() []int
+	GetConfigs(brokers []string) (map[string][]ConfigEntry, error)
+	IncrementalUpdateConfig(brokers []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error
+}
+
+type LogDirDescriber interface {
+	DescribeLogDirs(brokerIDs []int32) ([]LogDir, error)
 }
 
 type Deleter interface {
 	Delete(topics []string) error
 }
 
+type RecordDeleter interface {
+	DeleteRecords(topic string, partitionOffsets map[int32]int64) error
+}
+
 type Partitioner interface {
 	ReassignPartitions(topics []string, brokerList string, batch, timeoutPerBatchInS, pollIntervalInS, throttle int) error
 	IncreaseReplication(topicsMetadata []*TopicMetadata, replicationFactor, numOfBrokers, batch, timeoutPerBatchInS, pollIntervalInS, throttle int) error
 }
+
+type ACLAdmin interface {
+	ListACLs(topicRegex, groupRegex string) ([]ACL, error)
+	ListAllACLs() ([]ACL, error)
+	CreateACL(acl ACL) error
+	DeleteACL(acl ACL) error
+}
+
+type GroupOffsetter interface {
+	ListGroups(regex string) ([]string, error)
+	ListGroupsForTopics(topics []string) (map[string][]string, error)
+	GetOffsets(group string) (map[string]map[int32]int64, error)
+	CommitOffsets(group string, offsets map[string]map[int32]int64) error
+}
+
+type OffsetReader interface {
+	GetOffset(topic string, partition int32, timestamp int64) (int64, error)
+	GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error)
+	GetRecord(topic string, partition int32, offset int64) (Record, error)
+}
+
+type RackLister interface {
+	ListBrokerRacks() map[int32]string
+}
+
+type Producer interface {
+	Produce(records []Record) error
+	Close() error
+}
+
+type RecordConsumer interface {
+	Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(Record) bool) error
+}
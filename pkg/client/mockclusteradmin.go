@@ -89,6 +89,61 @@ func (m *MockClusterAdmin) DeleteConsumerGroup(group string) error {
 	return args.Error(0)
 }
 
+func (m *MockClusterAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	args := m.Called(topic, assignment)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) ListPartitionReassignments(topic string, partitions []int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	args := m.Called(topic, partitions)
+	return args.Get(0).(map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus), args.Error(1)
+}
+
+func (m *MockClusterAdmin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	args := m.Called(resourceType, name, entries, validateOnly)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) DeleteConsumerGroupOffset(group string, topic string, partition int32) error {
+	args := m.Called(group, topic, partition)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) DescribeLogDirs(brokers []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	args := m.Called(brokers)
+	return args.Get(0).(map[int32][]sarama.DescribeLogDirsResponseDirMetadata), args.Error(1)
+}
+
+func (m *MockClusterAdmin) DescribeUserScramCredentials(users []string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
+	args := m.Called(users)
+	return args.Get(0).([]*sarama.DescribeUserScramCredentialsResult), args.Error(1)
+}
+
+func (m *MockClusterAdmin) DeleteUserScramCredentials(delete []sarama.AlterUserScramCredentialsDelete) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	args := m.Called(delete)
+	return args.Get(0).([]*sarama.AlterUserScramCredentialsResult), args.Error(1)
+}
+
+func (m *MockClusterAdmin) UpsertUserScramCredentials(upsert []sarama.AlterUserScramCredentialsUpsert) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	args := m.Called(upsert)
+	return args.Get(0).([]*sarama.AlterUserScramCredentialsResult), args.Error(1)
+}
+
+func (m *MockClusterAdmin) DescribeClientQuotas(components []sarama.QuotaFilterComponent, strict bool) ([]sarama.DescribeClientQuotasEntry, error) {
+	args := m.Called(components, strict)
+	return args.Get(0).([]sarama.DescribeClientQuotasEntry), args.Error(1)
+}
+
+func (m *MockClusterAdmin) AlterClientQuotas(entity []sarama.QuotaEntityComponent, op sarama.ClientQuotasOp, validateOnly bool) error {
+	args := m.Called(entity, op, validateOnly)
+	return args.Error(0)
+}
+
+func (m *MockClusterAdmin) Controller() (*sarama.Broker, error) {
+	args := m.Called()
+	return args.Get(0).(*sarama.Broker), args.Error(1)
+}
+
 func (m *MockClusterAdmin) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -1,6 +1,10 @@
 package client
 
-import "github.com/stretchr/testify/mock"
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockKafkaAPIClient struct {
 	mock.Mock
@@ -46,7 +50,97 @@ func (m *MockKafkaAPIClient) GetTopicResourceType() int {
 	return args.Int(0)
 }
 
+func (m *MockKafkaAPIClient) GetBrokerResourceType() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
 func (m *MockKafkaAPIClient) GetConfig(resource ConfigResource) ([]ConfigEntry, error) {
 	args := m.Called(resource)
 	return args.Get(0).([]ConfigEntry), args.Error(1)
 }
+
+func (m *MockKafkaAPIClient) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	args := m.Called(topic, partitionOffsets)
+	return args.Error(0)
+}
+
+func (m *MockKafkaAPIClient) DescribeLogDirs(brokerIDs []int32) ([]LogDir, error) {
+	args := m.Called(brokerIDs)
+	return args.Get(0).([]LogDir), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error) {
+	args := m.Called(resources)
+	return args.Get(0).(map[string][]ConfigEntry), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) ListACLs() ([]ACL, error) {
+	args := m.Called()
+	return args.Get(0).([]ACL), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) CreateACL(acl ACL) error {
+	args := m.Called(acl)
+	return args.Error(0)
+}
+
+func (m *MockKafkaAPIClient) DeleteACL(acl ACL) error {
+	args := m.Called(acl)
+	return args.Error(0)
+}
+
+func (m *MockKafkaAPIClient) ListConsumerGroups() (map[string]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) GetConsumerGroupsForTopic(groups []string, topic string) (chan string, error) {
+	args := m.Called(groups, topic)
+	return args.Get(0).(chan string), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) GetConsumerGroupsForTopics(groups []string, topics []string) (map[string][]string, error) {
+	args := m.Called(groups, topics)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) GetConsumerGroupOffsets(group string) (map[string]map[int32]int64, error) {
+	args := m.Called(group)
+	return args.Get(0).(map[string]map[int32]int64), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) CommitConsumerGroupOffsets(group string, offsets map[string]map[int32]int64) error {
+	args := m.Called(group, offsets)
+	return args.Error(0)
+}
+
+func (m *MockKafkaAPIClient) GetOffset(topic string, partition int32, timestamp int64) (int64, error) {
+	args := m.Called(topic, partition, timestamp)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error) {
+	args := m.Called(topic, partition, offset)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) IncrementalUpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	args := m.Called(resourceType, name, entries, validateOnly)
+	return args.Error(0)
+}
+
+func (m *MockKafkaAPIClient) ListBrokerRacks() map[int32]string {
+	args := m.Called()
+	return args.Get(0).(map[int32]string)
+}
+
+func (m *MockKafkaAPIClient) GetRecord(topic string, partition int32, offset int64) (Record, error) {
+	args := m.Called(topic, partition, offset)
+	return args.Get(0).(Record), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(Record) bool) error {
+	args := m.Called(topic, startOffsets, endOffsets, stop, handle)
+	return args.Error(0)
+}
@@ -1,6 +1,10 @@
 package client
 
-import "github.com/stretchr/testify/mock"
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockCreator struct {
 	mock.Mock
@@ -25,8 +29,13 @@ func (m *MockLister) List() (map[string]TopicDetail, error) {
 	return args.Get(0).(map[string]TopicDetail), args.Error(1)
 }
 
-func (m *MockLister) ListLastWrittenTopics(lastWrittenEpoch int64, dataDir string) ([]string, error) {
-	args := m.Called(lastWrittenEpoch, dataDir)
+func (m *MockLister) ListLastWrittenTopics(lastWrittenEpoch int64, dataDir string, allowPartial bool) ([]string, error) {
+	args := m.Called(lastWrittenEpoch, dataDir, allowPartial)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLister) ListLastWrittenTopicsByTimestamp(lastWrittenEpoch int64) ([]string, error) {
+	args := m.Called(lastWrittenEpoch)
 	return args.Get(0).([]string), args.Error(1)
 }
 
@@ -53,11 +62,21 @@ func (m *MockConfigurer) GetConfig(topic string) ([]ConfigEntry, error) {
 	return args.Get(0).([]ConfigEntry), args.Error(1)
 }
 
+func (m *MockConfigurer) GetConfigs(topics []string) (map[string][]ConfigEntry, error) {
+	args := m.Called(topics)
+	return args.Get(0).(map[string][]ConfigEntry), args.Error(1)
+}
+
 func (m *MockConfigurer) UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error {
 	args := m.Called(topics, configMap, validateOnly)
 	return args.Error(0)
 }
 
+func (m *MockConfigurer) IncrementalUpdateConfig(topics []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	args := m.Called(topics, entries, validateOnly)
+	return args.Error(0)
+}
+
 type MockDeleter struct {
 	mock.Mock
 }
@@ -81,3 +100,151 @@ func (m *MockPartitioner) ReassignPartitions(topics []string, brokerList string,
 	args := m.Called(topics, brokerList, batch, timeoutPerBatchInS, pollIntervalInS, throttle)
 	return args.Error(0)
 }
+
+type MockACLAdmin struct {
+	mock.Mock
+}
+
+func (m *MockACLAdmin) ListACLs(topicRegex, groupRegex string) ([]ACL, error) {
+	args := m.Called(topicRegex, groupRegex)
+	return args.Get(0).([]ACL), args.Error(1)
+}
+
+func (m *MockACLAdmin) ListAllACLs() ([]ACL, error) {
+	args := m.Called()
+	return args.Get(0).([]ACL), args.Error(1)
+}
+
+func (m *MockACLAdmin) CreateACL(acl ACL) error {
+	args := m.Called(acl)
+	return args.Error(0)
+}
+
+func (m *MockACLAdmin) DeleteACL(acl ACL) error {
+	args := m.Called(acl)
+	return args.Error(0)
+}
+
+type MockGroupOffsetter struct {
+	mock.Mock
+}
+
+func (m *MockGroupOffsetter) ListGroups(regex string) ([]string, error) {
+	args := m.Called(regex)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGroupOffsetter) ListGroupsForTopics(topics []string) (map[string][]string, error) {
+	args := m.Called(topics)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockGroupOffsetter) GetOffsets(group string) (map[string]map[int32]int64, error) {
+	args := m.Called(group)
+	return args.Get(0).(map[string]map[int32]int64), args.Error(1)
+}
+
+func (m *MockGroupOffsetter) CommitOffsets(group string, offsets map[string]map[int32]int64) error {
+	args := m.Called(group, offsets)
+	return args.Error(0)
+}
+
+type MockOffsetReader struct {
+	mock.Mock
+}
+
+func (m *MockOffsetReader) GetOffset(topic string, partition int32, timestamp int64) (int64, error) {
+	args := m.Called(topic, partition, timestamp)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOffsetReader) GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error) {
+	args := m.Called(topic, partition, offset)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockOffsetReader) GetRecord(topic string, partition int32, offset int64) (Record, error) {
+	args := m.Called(topic, partition, offset)
+	return args.Get(0).(Record), args.Error(1)
+}
+
+type MockRackLister struct {
+	mock.Mock
+}
+
+func (m *MockRackLister) ListBrokerRacks() map[int32]string {
+	args := m.Called()
+	return args.Get(0).(map[int32]string)
+}
+
+type MockBrokerConfigurer struct {
+	mock.Mock
+}
+
+func (m *MockBrokerConfigurer) ListBrokerIDs() []int {
+	args := m.Called()
+	return args.Get(0).([]int)
+}
+
+func (m *MockBrokerConfigurer) GetConfigs(brokers []string) (map[string][]ConfigEntry, error) {
+	args := m.Called(brokers)
+	return args.Get(0).(map[string][]ConfigEntry), args.Error(1)
+}
+
+func (m *MockBrokerConfigurer) IncrementalUpdateConfig(brokers []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	args := m.Called(brokers, entries, validateOnly)
+	return args.Error(0)
+}
+
+type MockLogDirDescriber struct {
+	mock.Mock
+}
+
+func (m *MockLogDirDescriber) DescribeLogDirs(brokerIDs []int32) ([]LogDir, error) {
+	args := m.Called(brokerIDs)
+	return args.Get(0).([]LogDir), args.Error(1)
+}
+
+type MockRecordDeleter struct {
+	mock.Mock
+}
+
+func (m *MockRecordDeleter) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	args := m.Called(topic, partitionOffsets)
+	return args.Error(0)
+}
+
+type MockProducer struct {
+	mock.Mock
+}
+
+func (m *MockProducer) Produce(records []Record) error {
+	args := m.Called(records)
+	return args.Error(0)
+}
+
+func (m *MockProducer) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+type MockRecordConsumer struct {
+	mock.Mock
+}
+
+func (m *MockRecordConsumer) Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(Record) bool) error {
+	args := m.Called(topic, startOffsets, endOffsets, stop, handle)
+	return args.Error(0)
+}
+
+// ReplayRecords makes the mock consumer hand the records to the handler, until it asks to stop
+func ReplayRecords(records ...Record) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		handle := args.Get(4).(func(Record) bool)
+		for _, record := range records {
+			if !handle(record) {
+				return
+			}
+		}
+	}
+}
@@ -10,11 +10,18 @@ type MockSaramaClient struct {
 }
 
 func (m *MockSaramaClient) Config() *sarama.Config {
-	panic("implement me")
+	args := m.Called()
+	return args.Get(0).(*sarama.Config)
 }
 
 func (m *MockSaramaClient) Controller() (*sarama.Broker, error) {
-	panic("implement me")
+	args := m.Called()
+	return args.Get(0).(*sarama.Broker), args.Error(1)
+}
+
+func (m *MockSaramaClient) RefreshController() (*sarama.Broker, error) {
+	args := m.Called()
+	return args.Get(0).(*sarama.Broker), args.Error(1)
 }
 
 func (m *MockSaramaClient) Brokers() []*sarama.Broker {
@@ -22,6 +29,11 @@ func (m *MockSaramaClient) Brokers() []*sarama.Broker {
 	return args.Get(0).([]*sarama.Broker)
 }
 
+func (m *MockSaramaClient) Broker(brokerID int32) (*sarama.Broker, error) {
+	args := m.Called(brokerID)
+	return args.Get(0).(*sarama.Broker), args.Error(1)
+}
+
 func (m *MockSaramaClient) Topics() ([]string, error) {
 	panic("implement me")
 }
@@ -50,6 +62,11 @@ func (m *MockSaramaClient) OfflineReplicas(topic string, partitionID int32) ([]i
 	panic("implement me")
 }
 
+func (m *MockSaramaClient) RefreshBrokers(addrs []string) error {
+	args := m.Called(addrs)
+	return args.Error(0)
+}
+
 func (m *MockSaramaClient) RefreshMetadata(topics ...string) error {
 	panic("implement me")
 }
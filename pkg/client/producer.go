@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// Partitioners, acks and compressions of the producer
+const (
+	PartitionerHash       = "hash"
+	PartitionerRandom     = "random"
+	PartitionerRoundRobin = "round-robin"
+
+	AcksAll    = "all"
+	AcksLeader = "1"
+	AcksNone   = "0"
+
+	CompressionNone   = "none"
+	CompressionGZIP   = "gzip"
+	CompressionSnappy = "snappy"
+	CompressionLZ4    = "lz4"
+	CompressionZSTD   = "zstd"
+)
+
+var partitioners = map[string]sarama.PartitionerConstructor{
+	PartitionerHash:       sarama.NewHashPartitioner,
+	PartitionerRandom:     sarama.NewRandomPartitioner,
+	PartitionerRoundRobin: sarama.NewRoundRobinPartitioner,
+}
+
+var acks = map[string]sarama.RequiredAcks{
+	AcksAll:    sarama.WaitForAll,
+	AcksLeader: sarama.WaitForLocal,
+	AcksNone:   sarama.NoResponse,
+}
+
+var compressions = map[string]sarama.CompressionCodec{
+	CompressionNone:   sarama.CompressionNone,
+	CompressionGZIP:   sarama.CompressionGZIP,
+	CompressionSnappy: sarama.CompressionSnappy,
+	CompressionLZ4:    sarama.CompressionLZ4,
+	CompressionZSTD:   sarama.CompressionZSTD,
+}
+
+type ProducerConfig struct {
+	Partitioner string
+	Acks        string
+	Compression string
+	// BatchSize, BatchBytes and Linger bound the records buffered before a batch is sent, 0 leaves them unbounded
+	BatchSize  int
+	BatchBytes int
+	Linger     time.Duration
+}
+
+type SaramaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaProducer(addr []string, producerConfig ProducerConfig) (*SaramaProducer, error) {
+	cfg, err := newProducerConfig(producerConfig)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducer(addr, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("err on creating producer for %s - %v", addr, err)
+	}
+	return &SaramaProducer{producer: producer}, nil
+}
+
+func newProducerConfig(producerConfig ProducerConfig) (*sarama.Config, error) {
+	partitioner, ok := partitioners[producerConfig.Partitioner]
+	if !ok {
+		return nil, fmt.Errorf("unknown partitioner %v, should be one of %v, %v or %v", producerConfig.Partitioner,
+			PartitionerHash, PartitionerRandom, PartitionerRoundRobin)
+	}
+	requiredAcks, ok := acks[producerConfig.Acks]
+	if !ok {
+		return nil, fmt.Errorf("unknown acks %v, should be one of %v, %v or %v", producerConfig.Acks, AcksAll, AcksLeader, AcksNone)
+	}
+	compression, ok := compressions[producerConfig.Compression]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression %v, should be one of %v, %v, %v, %v or %v", producerConfig.Compression,
+			CompressionNone, CompressionGZIP, CompressionSnappy, CompressionLZ4, CompressionZSTD)
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_0_0_0
+	if compression == sarama.CompressionZSTD {
+		cfg.Version = sarama.V2_1_0_0
+	}
+	cfg.Producer.Partitioner = explicitPartitioner(partitioner)
+	cfg.Producer.RequiredAcks = requiredAcks
+	cfg.Producer.Compression = compression
+	cfg.Producer.Flush.Messages = producerConfig.BatchSize
+	cfg.Producer.Flush.Bytes = producerConfig.BatchBytes
+	cfg.Producer.Flush.Frequency = producerConfig.Linger
+	cfg.Producer.Return.Successes = true
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Produce sends the records in a batch and waits for all of them to be acknowledged. The records with a negative
+// partition are placed by the partitioner, and the ones without a timestamp are stamped by the producer.
+func (p *SaramaProducer) Produce(records []Record) error {
+	messages := make([]*sarama.ProducerMessage, 0, len(records))
+	for _, record := range records {
+		messages = append(messages, toProducerMessage(record))
+	}
+
+	err := p.producer.SendMessages(messages)
+	if err == nil {
+		return nil
+	}
+	if errs, ok := err.(sarama.ProducerErrors); ok && len(errs) != 0 {
+		return fmt.Errorf("err while producing %d of %d records - %v", len(errs), len(records), errs[0].Err)
+	}
+	return fmt.Errorf("err while producing records - %v", err)
+}
+
+func (p *SaramaProducer) Close() error {
+	return p.producer.Close()
+}
+
+func toProducerMessage(record Record) *sarama.ProducerMessage {
+	message := &sarama.ProducerMessage{Topic: record.Topic, Timestamp: record.Timestamp}
+	if record.Key != nil {
+		message.Key = sarama.ByteEncoder(record.Key)
+	}
+	// a nil value is left unset to produce a tombstone
+	if record.Value != nil {
+		message.Value = sarama.ByteEncoder(record.Value)
+	}
+	if record.Partition >= 0 {
+		message.Metadata = explicitPartition(record.Partition)
+	}
+	for _, header := range record.Headers {
+		message.Headers = append(message.Headers, sarama.RecordHeader{Key: []byte(header.Key), Value: header.Value})
+	}
+	return message
+}
+
+type explicitPartition int32
+
+// explicitPartitioner sends the messages with an explicit partition to it, and the others to the partition chosen by
+// the partitioner
+func explicitPartitioner(constructor sarama.PartitionerConstructor) sarama.PartitionerConstructor {
+	return func(topic string) sarama.Partitioner {
+		return &partitioner{Partitioner: constructor(topic)}
+	}
+}
+
+type partitioner struct {
+	sarama.Partitioner
+}
+
+func (p *partitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if partition, ok := message.Metadata.(explicitPartition); ok {
+		if int32(partition) >= numPartitions {
+			return -1, sarama.ErrInvalidPartition
+		}
+		return int32(partition), nil
+	}
+	return p.Partitioner.Partition(message, numPartitions)
+}
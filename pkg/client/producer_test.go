@@ -0,0 +1,94 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProducerConfig(t *testing.T) {
+	cfg, err := newProducerConfig(ProducerConfig{Partitioner: PartitionerRoundRobin, Acks: AcksLeader, Compression: CompressionZSTD,
+		BatchSize: 100, Linger: time.Second})
+
+	require.NoError(t, err)
+	assert.Equal(t, sarama.WaitForLocal, cfg.Producer.RequiredAcks)
+	assert.Equal(t, sarama.CompressionZSTD, cfg.Producer.Compression)
+	assert.Equal(t, sarama.V2_1_0_0, cfg.Version)
+	assert.Equal(t, 100, cfg.Producer.Flush.Messages)
+	assert.Equal(t, time.Second, cfg.Producer.Flush.Frequency)
+}
+
+func TestNewProducerConfig_Invalid(t *testing.T) {
+	_, err := newProducerConfig(ProducerConfig{Partitioner: "murmur2", Acks: AcksAll, Compression: CompressionNone})
+	assert.EqualError(t, err, "unknown partitioner murmur2, should be one of hash, random or round-robin")
+
+	_, err = newProducerConfig(ProducerConfig{Partitioner: PartitionerHash, Acks: "-1", Compression: CompressionNone})
+	assert.EqualError(t, err, "unknown acks -1, should be one of all, 1 or 0")
+
+	_, err = newProducerConfig(ProducerConfig{Partitioner: PartitionerHash, Acks: AcksAll, Compression: "brotli"})
+	assert.EqualError(t, err, "unknown compression brotli, should be one of none, gzip, snappy, lz4 or zstd")
+}
+
+func TestExplicitPartitioner(t *testing.T) {
+	p := explicitPartitioner(sarama.NewManualPartitioner)("topic-1")
+
+	partition, err := p.Partition(toProducerMessage(Record{Topic: "topic-1", Partition: 2}), 3)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), partition)
+
+	_, err = p.Partition(toProducerMessage(Record{Topic: "topic-1", Partition: 3}), 3)
+	assert.Equal(t, sarama.ErrInvalidPartition, err)
+
+	message := toProducerMessage(Record{Topic: "topic-1", Partition: -1})
+	message.Partition = 1
+	partition, err = p.Partition(message, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), partition)
+}
+
+func TestToProducerMessage(t *testing.T) {
+	timestamp := time.Unix(1500000000, 0)
+	message := toProducerMessage(Record{Topic: "topic-1", Partition: -1, Key: []byte("key"), Value: []byte("value"),
+		Headers: []RecordHeader{{Key: "h1", Value: []byte("v1")}}, Timestamp: timestamp})
+
+	assert.Equal(t, sarama.ByteEncoder("key"), message.Key)
+	assert.Equal(t, sarama.ByteEncoder("value"), message.Value)
+	assert.Equal(t, []sarama.RecordHeader{{Key: []byte("h1"), Value: []byte("v1")}}, message.Headers)
+	assert.Equal(t, timestamp, message.Timestamp)
+	assert.Nil(t, message.Metadata)
+
+	tombstone := toProducerMessage(Record{Topic: "topic-1", Key: []byte("key")})
+	assert.Nil(t, tombstone.Value)
+}
+
+func TestSaramaProducer_Produce(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Producer.Return.Successes = true
+	mockProducer := mocks.NewSyncProducer(t, cfg)
+	mockProducer.ExpectSendMessageAndSucceed()
+	mockProducer.ExpectSendMessageAndSucceed()
+	producer := SaramaProducer{producer: mockProducer}
+
+	err := producer.Produce([]Record{{Topic: "topic-1", Value: []byte("1")}, {Topic: "topic-1", Value: []byte("2")}})
+
+	assert.NoError(t, err)
+	assert.NoError(t, producer.Close())
+}
+
+func TestSaramaProducer_ProduceFailure(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Producer.Return.Successes = true
+	mockProducer := mocks.NewSyncProducer(t, cfg)
+	mockProducer.ExpectSendMessageAndSucceed()
+	mockProducer.ExpectSendMessageAndFail(sarama.ErrMessageSizeTooLarge)
+	producer := SaramaProducer{producer: mockProducer}
+
+	err := producer.Produce([]Record{{Topic: "topic-1", Value: []byte("1")}, {Topic: "topic-1", Value: []byte("2")}})
+
+	assert.EqualError(t, err, "err while producing records - "+sarama.ErrMessageSizeTooLarge.Error())
+	assert.NoError(t, producer.Close())
+}
@@ -2,12 +2,21 @@ package client
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/gojek/kat/logger"
 )
 
+const (
+	recordFetchTimeout         = 10 * time.Second
+	describeConfigsBatchSize   = 100
+	incrementalAlterConfigsKey = 44
+)
+
 type SaramaClient struct {
 	admin  sarama.ClusterAdmin
 	client sarama.Client
@@ -15,15 +24,16 @@ type SaramaClient struct {
 
 type consumerGroups map[string]*sarama.GroupMemberDescription
 
+// HasSubscription reports whether any of the members of the group is assigned partitions of the topic
 func (c *consumerGroups) HasSubscription(topic string) bool {
 	for _, memberDesc := range *c {
-		ma, _ := memberDesc.GetMemberAssignment()
-		for topicName := range ma.Topics {
-			if topicName == topic {
-				return true
-			}
+		ma, err := memberDesc.GetMemberAssignment()
+		if err != nil {
+			continue
+		}
+		if _, ok := ma.Topics[topic]; ok {
+			return true
 		}
-		break
 	}
 	return false
 }
@@ -67,37 +77,299 @@ func (s *SaramaClient) ListBrokers() map[int]string {
 	return brokerMap
 }
 
+// ListBrokerRacks returns the rack of every broker, empty for the brokers without broker.rack
+func (s *SaramaClient) ListBrokerRacks() map[int32]string {
+	racks := make(map[int32]string)
+	for _, broker := range s.client.Brokers() {
+		racks[broker.ID()] = broker.Rack()
+	}
+	return racks
+}
+
 func (s *SaramaClient) ListConsumerGroups() (map[string]string, error) {
 	return s.admin.ListConsumerGroups()
 }
 
+// GetConsumerGroupsForTopic returns the groups having members subscribed to the topic
 func (s *SaramaClient) GetConsumerGroupsForTopic(groups []string, topic string) (chan string, error) {
-	var wg sync.WaitGroup
+	descriptions, err := s.describeConsumerGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+
 	consumerGroupsChannel := make(chan string, len(groups))
+	for group, members := range descriptions {
+		if members.HasSubscription(topic) {
+			consumerGroupsChannel <- group
+		}
+	}
+	close(consumerGroupsChannel)
+	return consumerGroupsChannel, nil
+}
+
+// GetConsumerGroupsForTopics returns the groups having members subscribed to each of the topics, describing every
+// group once
+func (s *SaramaClient) GetConsumerGroupsForTopics(groups []string, topics []string) (map[string][]string, error) {
+	descriptions, err := s.describeConsumerGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed := make(map[string][]string)
+	for group, members := range descriptions {
+		for _, topic := range topics {
+			if members.HasSubscription(topic) {
+				subscribed[topic] = append(subscribed[topic], group)
+			}
+		}
+	}
+	return subscribed, nil
+}
+
+// describeConsumerGroups describes the groups in parallel, returning the members of every group
+func (s *SaramaClient) describeConsumerGroups(groups []string) (map[string]consumerGroups, error) {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var errs []string
+	descriptions := make(map[string]consumerGroups)
 
 	for i := 0; i < len(groups); i++ {
 		wg.Add(1)
 		go func(i int, wg *sync.WaitGroup) {
 			defer wg.Done()
 			groupDescription, err := s.admin.DescribeConsumerGroups([]string{groups[i]})
+			if err == nil && len(groupDescription) == 0 {
+				err = fmt.Errorf("no description returned")
+			}
+			lock.Lock()
+			defer lock.Unlock()
 			if err != nil {
-				logger.Fatalf("Err on describing consumer group %s: %v\n", groups[i], err)
+				logger.Errorf("Err on describing consumer group %s: %v\n", groups[i], err)
+				errs = append(errs, fmt.Sprintf("%v - %v", groups[i], err))
+				return
 			}
+			descriptions[groupDescription[0].GroupId] = groupDescription[0].Members
+		}(i, &wg)
+	}
 
-			var c consumerGroups = groupDescription[0].Members
+	wg.Wait()
+
+	if len(errs) != 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("err while describing consumer groups: %v", strings.Join(errs, ", "))
+	}
+	return descriptions, nil
+}
 
-			if c.HasSubscription(topic) {
-				consumerGroupsChannel <- groupDescription[0].GroupId
-				fmt.Println(groupDescription[0].GroupId)
+func (s *SaramaClient) GetConsumerGroupOffsets(group string) (map[string]map[int32]int64, error) {
+	response, err := s.admin.ListConsumerGroupOffsets(group, nil)
+	if err == nil && response.Err != sarama.ErrNoError {
+		err = response.Err
+	}
+	if err != nil {
+		logger.Errorf("Err while fetching offsets for consumer group %v - %v\n", group, err)
+		return nil, err
+	}
+
+	offsets := make(map[string]map[int32]int64)
+	for topic, blocks := range response.Blocks {
+		for partition, block := range blocks {
+			if block.Err != sarama.ErrNoError {
+				return nil, fmt.Errorf("err while fetching offset of %v-%v for consumer group %v - %v", topic, partition, group, block.Err)
 			}
-		}(i, &wg)
+			if block.Offset < 0 {
+				continue
+			}
+			if offsets[topic] == nil {
+				offsets[topic] = make(map[int32]int64)
+			}
+			offsets[topic][partition] = block.Offset
+		}
 	}
+	return offsets, nil
+}
 
-	wg.Wait()
+// CommitConsumerGroupOffsets commits the offsets outside of a consumer group generation,
+// which the coordinator only accepts while the group has no active members.
+func (s *SaramaClient) CommitConsumerGroupOffsets(group string, offsets map[string]map[int32]int64) error {
+	coordinator, err := s.client.Coordinator(group)
+	if err != nil {
+		logger.Errorf("Err while finding coordinator for consumer group %v - %v\n", group, err)
+		return err
+	}
 
-	close(consumerGroupsChannel)
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           group,
+		ConsumerGroupGeneration: -1,
+		RetentionTime:           -1,
+	}
+	for topic, partitions := range offsets {
+		for partition, offset := range partitions {
+			request.AddBlock(topic, partition, offset, 0, "")
+		}
+	}
 
-	return consumerGroupsChannel, nil
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		logger.Errorf("Err while committing offsets for consumer group %v - %v\n", group, err)
+		return err
+	}
+	for topic, partitions := range response.Errors {
+		for partition, kerr := range partitions {
+			if kerr != sarama.ErrNoError {
+				return fmt.Errorf("err while committing offset of %v-%v for consumer group %v - %v", topic, partition, group, kerr)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SaramaClient) GetOffset(topic string, partition int32, timestamp int64) (int64, error) {
+	return s.client.GetOffset(topic, partition, timestamp)
+}
+
+func (s *SaramaClient) GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error) {
+	record, err := s.GetRecord(topic, partition, offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return record.Timestamp, nil
+}
+
+// GetRecord fetches the first record at or after the offset
+func (s *SaramaClient) GetRecord(topic string, partition int32, offset int64) (Record, error) {
+	consumer, err := sarama.NewConsumerFromClient(s.client)
+	if err != nil {
+		return Record{}, err
+	}
+	defer consumer.Close()
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return Record{}, err
+	}
+	defer partitionConsumer.AsyncClose()
+
+	select {
+	case message := <-partitionConsumer.Messages():
+		return toRecord(message), nil
+	case <-time.After(recordFetchTimeout):
+		return Record{}, fmt.Errorf("timed out while fetching record at offset %v of %v-%v", offset, topic, partition)
+	}
+}
+
+// Consume calls handle with the records of the partitions from the start offsets, in the order they are fetched across
+// the partitions. It stops when handle returns false, the stop channel is closed, or every partition reaches its end
+// offset. Without end offsets, it consumes until stopped. As the offsets before the end can be transaction markers or
+// compacted away, a partition also reaches its end on a record past the end offset. When no record before the end
+// offsets is fetched within the record fetch timeout, it returns an error listing the partitions not read to the end.
+func (s *SaramaClient) Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(Record) bool) error {
+	consumer, err := sarama.NewConsumerFromClient(s.client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	messages := make(chan *sarama.ConsumerMessage)
+	done := make(chan struct{})
+	defer close(done)
+	isEnded := make(map[int32]bool)
+	pending := 0
+	for partition, offset := range startOffsets {
+		if endOffsets != nil && offset >= endOffsets[partition] {
+			continue
+		}
+		partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+		if err != nil {
+			return fmt.Errorf("err while consuming %v-%v - %v", topic, partition, err)
+		}
+		defer partitionConsumer.AsyncClose()
+		pending++
+		go forwardMessages(partitionConsumer, messages, done)
+	}
+
+	var idle *time.Timer
+	var idleC <-chan time.Time
+	if endOffsets != nil {
+		idle = time.NewTimer(recordFetchTimeout)
+		defer idle.Stop()
+		idleC = idle.C
+	}
+	endPartition := func(partition int32) {
+		isEnded[partition] = true
+		pending--
+	}
+	for endOffsets == nil || pending > 0 {
+		select {
+		case message := <-messages:
+			if endOffsets != nil {
+				if isEnded[message.Partition] {
+					continue
+				}
+				if message.Offset >= endOffsets[message.Partition] {
+					endPartition(message.Partition)
+					continue
+				}
+				if !idle.Stop() {
+					<-idle.C
+				}
+				idle.Reset(recordFetchTimeout)
+			}
+			if !handle(toRecord(message)) {
+				return nil
+			}
+			if endOffsets != nil && message.Offset+1 >= endOffsets[message.Partition] {
+				endPartition(message.Partition)
+			}
+		case <-stop:
+			return nil
+		case <-idleC:
+			var unfinished []int32
+			for partition := range startOffsets {
+				if startOffsets[partition] < endOffsets[partition] && !isEnded[partition] {
+					unfinished = append(unfinished, partition)
+				}
+			}
+			sort.Slice(unfinished, func(i, j int) bool { return unfinished[i] < unfinished[j] })
+			return fmt.Errorf("err while consuming %v - no records fetched within %v, partitions %v were not read up to their end offsets",
+				topic, recordFetchTimeout, unfinished)
+		}
+	}
+	return nil
+}
+
+func forwardMessages(partitionConsumer sarama.PartitionConsumer, messages chan<- *sarama.ConsumerMessage, done <-chan struct{}) {
+	for {
+		select {
+		case message, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return
+			}
+			select {
+			case messages <- message:
+			case <-done:
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func toRecord(message *sarama.ConsumerMessage) Record {
+	record := Record{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
+		Key:       message.Key,
+		Value:     message.Value,
+		Timestamp: message.Timestamp,
+	}
+	for _, header := range message.Headers {
+		record.Headers = append(record.Headers, RecordHeader{Key: string(header.Key), Value: header.Value})
+	}
+	return record
 }
 
 func (s *SaramaClient) ListTopicDetails() (map[string]TopicDetail, error) {
@@ -120,18 +392,29 @@ func (s *SaramaClient) ListTopicDetails() (map[string]TopicDetail, error) {
 	return topicDetails, err
 }
 
+// DeleteTopic attempts to delete all the topics, returning the errors of the topics that could not be deleted
 func (s *SaramaClient) DeleteTopic(topics []string) error {
+	var errs []string
 	for _, topic := range topics {
 		err := s.admin.DeleteTopic(topic)
 		if err != nil {
-			logger.Errorf("Error while deleting topic %v- %v\n", topic, err)
+			logger.Errorf("Error while deleting topic %v - %v\n", topic, err)
+			errs = append(errs, fmt.Sprintf("%v - %v", topic, err))
 		} else {
 			logger.Infof("Deleted topic - %v\n", topic)
 		}
 	}
+	if len(errs) != 0 {
+		return fmt.Errorf("err while deleting %d of %d topics: %v", len(errs), len(topics), strings.Join(errs, ", "))
+	}
 	return nil
 }
 
+// DeleteRecords deletes the records of the partitions before the given offsets, moving their low watermarks
+func (s *SaramaClient) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	return s.admin.DeleteRecords(topic, partitionOffsets)
+}
+
 func (s *SaramaClient) DescribeTopicMetadata(topics []string) ([]*TopicMetadata, error) {
 	metadata, err := s.admin.DescribeTopics(topics)
 	if err != nil {
@@ -164,18 +447,197 @@ func (s *SaramaClient) DescribeTopicMetadata(topics []string) ([]*TopicMetadata,
 }
 
 func (s *SaramaClient) UpdateConfig(resourceType int, name string, entries map[string]*string, validateOnly bool) error {
-	err := s.admin.AlterConfig(sarama.ConfigResourceType(resourceType), name, entries, validateOnly)
+	var err error
+	if isBrokerResource(resourceType, name) {
+		err = s.alterBrokerConfig(name, entries, validateOnly)
+	} else {
+		err = s.admin.AlterConfig(sarama.ConfigResourceType(resourceType), name, entries, validateOnly)
+	}
 	if err != nil {
-		logger.Errorf("Error while changing config for topic %v - %v\n", name, err)
+		logger.Errorf("Error while changing config for %v - %v\n", name, err)
 	}
 	return err
 }
 
+// IncrementalUpdateConfig applies the operations to the configs of the resource, leaving its other configs as they
+// are. Brokers older than 2.3 can not alter configs incrementally, in which case the configs set on the resource are
+// altered as a whole instead.
+func (s *SaramaClient) IncrementalUpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	broker, err := s.brokerFor(resourceType, name)
+	if err != nil {
+		return err
+	}
+	supported, err := s.supportsIncrementalAlterConfigs(broker)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		logger.Warnf("Broker %v is older than 2.3, the configs of %v are altered as a whole and overrides changed by someone else meanwhile are lost\n", broker.Addr(), name)
+		return s.replaceConfig(broker, resourceType, name, entries, validateOnly)
+	}
+
+	err = s.incrementalAlterConfig(broker, resourceType, name, entries, validateOnly)
+	if err != nil {
+		logger.Errorf("Error while changing config for %v - %v\n", name, err)
+	}
+	return err
+}
+
+func (s *SaramaClient) supportsIncrementalAlterConfigs(broker *sarama.Broker) (bool, error) {
+	if err := s.open(broker); err != nil {
+		return false, err
+	}
+	response, err := broker.ApiVersions(&sarama.ApiVersionsRequest{})
+	if err != nil {
+		return false, fmt.Errorf("err while fetching api versions of broker %v - %v", broker.Addr(), err)
+	}
+	for _, key := range response.ApiKeys {
+		if key.ApiKey == incrementalAlterConfigsKey {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// incrementalAlterConfig sends the operations over a connection of its own to the broker, as sarama only sends
+// IncrementalAlterConfigs on connections made for Kafka 2.3 or later
+func (s *SaramaClient) incrementalAlterConfig(broker *sarama.Broker, resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	config := *s.client.Config()
+	config.Version = sarama.V2_3_0_0
+	incrementalBroker := sarama.NewBroker(broker.Addr())
+	if err := incrementalBroker.Open(&config); err != nil {
+		return fmt.Errorf("err while connecting to broker %v - %v", broker.Addr(), err)
+	}
+	defer incrementalBroker.Close()
+
+	configEntries := make(map[string]sarama.IncrementalAlterConfigsEntry)
+	for configName, entry := range entries {
+		configEntries[configName] = sarama.IncrementalAlterConfigsEntry{Operation: incrementalOperations[entry.Operation], Value: entry.Value}
+	}
+	response, err := incrementalBroker.IncrementalAlterConfigs(&sarama.IncrementalAlterConfigsRequest{
+		Resources:    []*sarama.IncrementalAlterConfigsResource{{Type: sarama.ConfigResourceType(resourceType), Name: name, ConfigEntries: configEntries}},
+		ValidateOnly: validateOnly,
+	})
+	if err != nil {
+		return err
+	}
+	for _, resource := range response.Resources {
+		if resource.ErrorCode != int16(sarama.ErrNoError) {
+			return fmt.Errorf("%v - %v", sarama.KError(resource.ErrorCode), resource.ErrorMsg)
+		}
+	}
+	return nil
+}
+
+// replaceConfig applies the operations on top of the configs currently set on the resource. AlterConfigs replaces
+// every dynamic config of the resource, so the current overrides are read and sent back along with the altered
+// entries. Overrides changed by someone else in between the two calls are lost.
+func (s *SaramaClient) replaceConfig(broker *sarama.Broker, resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	current, err := s.describeConfigs(broker, []*sarama.ConfigResource{{Type: sarama.ConfigResourceType(resourceType), Name: name}})
+	if err != nil {
+		logger.Errorf("Error while retrieving config for %v - %v\n", name, err)
+		return err
+	}
+
+	configs := make(map[string]*string)
+	currentValues := make(map[string]string)
+	for _, entry := range current[name] {
+		currentValues[entry.Name] = entry.Value
+		if entry.ReadOnly || entry.Source != overrideSource(resourceType, name).String() {
+			continue
+		}
+		if _, ok := entries[entry.Name]; !ok && entry.Sensitive {
+			return fmt.Errorf("sensitive config %v of %v cannot be retained while altering other configs", entry.Name, name)
+		}
+		value := entry.Value
+		configs[entry.Name] = &value
+	}
+
+	for configName, entry := range entries {
+		if value := entry.Apply(currentValues[configName]); value != nil {
+			configs[configName] = value
+		} else {
+			delete(configs, configName)
+		}
+	}
+
+	return s.UpdateConfig(resourceType, name, configs, validateOnly)
+}
+
+func (s *SaramaClient) alterBrokerConfig(name string, entries map[string]*string, validateOnly bool) error {
+	broker, err := s.brokerFor(int(sarama.BrokerResource), name)
+	if err != nil {
+		return err
+	}
+	if err := s.open(broker); err != nil {
+		return err
+	}
+
+	response, err := broker.AlterConfigs(&sarama.AlterConfigsRequest{
+		Resources:    []*sarama.AlterConfigsResource{{Type: sarama.BrokerResource, Name: name, ConfigEntries: entries}},
+		ValidateOnly: validateOnly,
+	})
+	if err != nil {
+		return err
+	}
+	for _, resource := range response.Resources {
+		if resource.ErrorCode != int16(sarama.ErrNoError) {
+			return fmt.Errorf("%v - %v", sarama.KError(resource.ErrorCode), resource.ErrorMsg)
+		}
+	}
+	return nil
+}
+
+// brokerFor returns the broker to which the config requests of the resource are sent. Configs of a broker can
+// only be described and altered on the broker itself, the rest are handled by any broker.
+func (s *SaramaClient) brokerFor(resourceType int, name string) (*sarama.Broker, error) {
+	if !isBrokerResource(resourceType, name) {
+		return s.client.Controller()
+	}
+	for _, broker := range s.client.Brokers() {
+		if fmt.Sprint(broker.ID()) == name {
+			return broker, nil
+		}
+	}
+	return nil, fmt.Errorf("broker %v not found in the cluster", name)
+}
+
+func (s *SaramaClient) open(broker *sarama.Broker) error {
+	err := broker.Open(s.client.Config())
+	if err != nil && err != sarama.ErrAlreadyConnected {
+		return fmt.Errorf("err while connecting to broker %v - %v", broker.Addr(), err)
+	}
+	return nil
+}
+
+func isBrokerResource(resourceType int, name string) bool {
+	return sarama.ConfigResourceType(resourceType) == sarama.BrokerResource && name != ""
+}
+
+// overrideSource is the source of the configs set dynamically on the resource
+func overrideSource(resourceType int, name string) sarama.ConfigSource {
+	switch {
+	case isBrokerResource(resourceType, name):
+		return sarama.SourceDynamicBroker
+	case sarama.ConfigResourceType(resourceType) == sarama.BrokerResource:
+		return sarama.SourceDynamicDefaultBroker
+	}
+	return sarama.SourceTopic
+}
+
 func (s *SaramaClient) GetTopicResourceType() int {
 	return int(sarama.TopicResource)
 }
 
+func (s *SaramaClient) GetBrokerResourceType() int {
+	return int(sarama.BrokerResource)
+}
+
 func (s *SaramaClient) GetConfig(resource ConfigResource) ([]ConfigEntry, error) {
+	if sarama.ConfigResourceType(resource.Type) == sarama.BrokerResource {
+		return s.getBrokerConfig(resource)
+	}
+
 	entries, err := s.admin.DescribeConfig(sarama.ConfigResource{
 		Type:        sarama.ConfigResourceType(resource.Type),
 		Name:        resource.Name,
@@ -187,26 +649,317 @@ func (s *SaramaClient) GetConfig(resource ConfigResource) ([]ConfigEntry, error)
 	}
 
 	var configEntries []ConfigEntry
-	for _, e := range entries {
-		var configSynonyms []*ConfigSynonym
-		for _, s := range e.Synonyms {
-			configSynonyms = append(configSynonyms, &ConfigSynonym{
-				ConfigName:  s.ConfigName,
-				ConfigValue: s.ConfigValue,
-				Source:      s.Source.String(),
+	for i := range entries {
+		configEntries = append(configEntries, toConfigEntry(&entries[i]))
+	}
+
+	return configEntries, nil
+}
+
+func (s *SaramaClient) getBrokerConfig(resource ConfigResource) ([]ConfigEntry, error) {
+	broker, err := s.brokerFor(resource.Type, resource.Name)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := s.describeConfigs(broker, []*sarama.ConfigResource{{
+		Type:        sarama.BrokerResource,
+		Name:        resource.Name,
+		ConfigNames: resource.ConfigNames,
+	}})
+	if err != nil {
+		logger.Errorf("Error while retrieving config for %v - %v\n", resource.Name, err)
+		return nil, err
+	}
+	return configs[resource.Name], nil
+}
+
+// GetConfigs describes the resources in batches of describeConfigsBatchSize, spreading the batches across the
+// brokers with one in-flight request per broker. Topic resources can be described by any broker.
+func (s *SaramaClient) GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error) {
+	configs := make(map[string][]ConfigEntry)
+	if len(resources) == 0 {
+		return configs, nil
+	}
+
+	brokers := s.client.Brokers()
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("no brokers available to describe configs")
+	}
+
+	batches := make(chan []*sarama.ConfigResource, len(resources)/describeConfigsBatchSize+1)
+	for start := 0; start < len(resources); start += describeConfigsBatchSize {
+		end := start + describeConfigsBatchSize
+		if end > len(resources) {
+			end = len(resources)
+		}
+		var batch []*sarama.ConfigResource
+		for _, resource := range resources[start:end] {
+			batch = append(batch, &sarama.ConfigResource{
+				Type:        sarama.ConfigResourceType(resource.Type),
+				Name:        resource.Name,
+				ConfigNames: resource.ConfigNames,
 			})
 		}
+		batches <- batch
+	}
+	close(batches)
+
+	workers := len(brokers)
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fetched  int
+		firstErr error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(broker *sarama.Broker) {
+			defer wg.Done()
+			for batch := range batches {
+				mu.Lock()
+				failed := firstErr != nil
+				mu.Unlock()
+				if failed {
+					continue
+				}
+
+				entries, err := s.describeConfigs(broker, batch)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					for name, e := range entries {
+						configs[name] = e
+					}
+					fetched += len(batch)
+					logger.Infof("Fetched configs for %d/%d resources\n", fetched, len(resources))
+				}
+				mu.Unlock()
+			}
+		}(brokers[i])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		logger.Errorf("Error while retrieving configs - %v\n", firstErr)
+		return nil, firstErr
+	}
+	return configs, nil
+}
 
-		configEntries = append(configEntries, ConfigEntry{
-			Name:      e.Name,
-			Value:     e.Value,
-			ReadOnly:  e.ReadOnly,
-			Default:   e.Default,
-			Source:    e.Source.String(),
-			Sensitive: e.Sensitive,
-			Synonyms:  configSynonyms,
+func (s *SaramaClient) describeConfigs(broker *sarama.Broker, resources []*sarama.ConfigResource) (map[string][]ConfigEntry, error) {
+	if err := s.open(broker); err != nil {
+		return nil, err
+	}
+
+	response, err := broker.DescribeConfigs(&sarama.DescribeConfigsRequest{Version: 1, Resources: resources})
+	if err != nil {
+		return nil, fmt.Errorf("err while describing configs on broker %v - %v", broker.Addr(), err)
+	}
+
+	configs := make(map[string][]ConfigEntry)
+	for _, resource := range response.Resources {
+		if resource.ErrorMsg != "" {
+			return nil, fmt.Errorf("err while describing config for %v - %v", resource.Name, resource.ErrorMsg)
+		}
+		var configEntries []ConfigEntry
+		for _, e := range resource.Configs {
+			configEntries = append(configEntries, toConfigEntry(e))
+		}
+		configs[resource.Name] = configEntries
+	}
+	return configs, nil
+}
+
+// DescribeLogDirs describes the log dirs of the given brokers, or of all the brokers when none are given
+func (s *SaramaClient) DescribeLogDirs(brokerIDs []int32) ([]LogDir, error) {
+	brokers := make(map[int32]*sarama.Broker)
+	for _, broker := range s.client.Brokers() {
+		brokers[broker.ID()] = broker
+	}
+	if len(brokerIDs) == 0 {
+		for id := range brokers {
+			brokerIDs = append(brokerIDs, id)
+		}
+	}
+	sort.Slice(brokerIDs, func(i, j int) bool {
+		return brokerIDs[i] < brokerIDs[j]
+	})
+
+	var logDirs []LogDir
+	for _, id := range brokerIDs {
+		broker, ok := brokers[id]
+		if !ok {
+			return nil, fmt.Errorf("broker %v not found in the cluster", id)
+		}
+		if err := s.open(broker); err != nil {
+			return nil, err
+		}
+
+		response, err := broker.DescribeLogDirs(&sarama.DescribeLogDirsRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("err while describing log dirs on broker %v - %v", id, err)
+		}
+		logDirs = append(logDirs, toLogDirs(id, response)...)
+	}
+	return logDirs, nil
+}
+
+func toLogDirs(brokerID int32, response *sarama.DescribeLogDirsResponse) []LogDir {
+	var logDirs []LogDir
+	for _, dir := range response.LogDirs {
+		logDir := LogDir{Broker: brokerID, Path: dir.Path}
+		if dir.ErrorCode != sarama.ErrNoError {
+			logDir.Err = dir.ErrorCode
+		}
+		for _, topic := range dir.Topics {
+			for _, partition := range topic.Partitions {
+				logDir.Replicas = append(logDir.Replicas, ReplicaLog{
+					Topic:       topic.Topic,
+					Partition:   partition.PartitionID,
+					Size:        partition.Size,
+					OffsetLag:   partition.OffsetLag,
+					IsTemporary: partition.IsTemporary,
+				})
+			}
+		}
+		logDirs = append(logDirs, logDir)
+	}
+	return logDirs
+}
+
+func toConfigEntry(e *sarama.ConfigEntry) ConfigEntry {
+	var configSynonyms []*ConfigSynonym
+	for _, s := range e.Synonyms {
+		configSynonyms = append(configSynonyms, &ConfigSynonym{
+			ConfigName:  s.ConfigName,
+			ConfigValue: s.ConfigValue,
+			Source:      s.Source.String(),
 		})
 	}
 
-	return configEntries, nil
+	// Only version 0 of the response carries the default flag, later versions carry the source of the value
+	isDefault := e.Default
+	if e.Source != sarama.SourceUnknown {
+		isDefault = e.Source == sarama.SourceDefault
+	}
+
+	return ConfigEntry{
+		Name:      e.Name,
+		Value:     e.Value,
+		ReadOnly:  e.ReadOnly,
+		Default:   isDefault,
+		Source:    e.Source.String(),
+		Sensitive: e.Sensitive,
+		Synonyms:  configSynonyms,
+	}
+}
+
+func (s *SaramaClient) ListACLs() ([]ACL, error) {
+	resourceACLs, err := s.admin.ListAcls(sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+	})
+	if err != nil {
+		logger.Errorf("Error while listing acls - %v\n", err)
+		return nil, err
+	}
+
+	var acls []ACL
+	for _, resourceACL := range resourceACLs {
+		for _, acl := range resourceACL.Acls {
+			acls = append(acls, ACL{
+				ResourceType:   aclName(aclResourceTypes, int(resourceACL.ResourceType)),
+				ResourceName:   resourceACL.ResourceName,
+				PatternType:    aclName(aclPatternTypes, int(resourceACL.ResourcePatternType)),
+				Principal:      acl.Principal,
+				Host:           acl.Host,
+				Operation:      aclName(aclOperations, int(acl.Operation)),
+				PermissionType: aclName(aclPermissionTypes, int(acl.PermissionType)),
+			})
+		}
+	}
+	return acls, nil
+}
+
+func (s *SaramaClient) CreateACL(acl ACL) error {
+	resource := sarama.Resource{
+		ResourceType:        sarama.AclResourceType(aclValue(aclResourceTypes, acl.ResourceType)),
+		ResourceName:        acl.ResourceName,
+		ResourcePatternType: sarama.AclResourcePatternType(aclValue(aclPatternTypes, acl.PatternType)),
+	}
+	err := s.admin.CreateACL(resource, sarama.Acl{
+		Principal:      acl.Principal,
+		Host:           acl.Host,
+		Operation:      sarama.AclOperation(aclValue(aclOperations, acl.Operation)),
+		PermissionType: sarama.AclPermissionType(aclValue(aclPermissionTypes, acl.PermissionType)),
+	})
+	if err != nil {
+		logger.Errorf("Error while creating acl for %v %v - %v\n", acl.ResourceType, acl.ResourceName, err)
+	}
+	return err
+}
+
+func (s *SaramaClient) DeleteACL(acl ACL) error {
+	matchingACLs, err := s.admin.DeleteACL(sarama.AclFilter{
+		ResourceType:              sarama.AclResourceType(aclValue(aclResourceTypes, acl.ResourceType)),
+		ResourceName:              &acl.ResourceName,
+		ResourcePatternTypeFilter: sarama.AclResourcePatternType(aclValue(aclPatternTypes, acl.PatternType)),
+		Principal:                 &acl.Principal,
+		Host:                      &acl.Host,
+		Operation:                 sarama.AclOperation(aclValue(aclOperations, acl.Operation)),
+		PermissionType:            sarama.AclPermissionType(aclValue(aclPermissionTypes, acl.PermissionType)),
+	}, false)
+	if err == nil {
+		for _, matchingACL := range matchingACLs {
+			if matchingACL.Err != sarama.ErrNoError {
+				err = matchingACL.Err
+				break
+			}
+		}
+	}
+	if err != nil {
+		logger.Errorf("Error while deleting acl for %v %v - %v\n", acl.ResourceType, acl.ResourceName, err)
+	}
+	return err
+}
+
+// The acl enums in sarama are iota based, so the names are indexed by their values
+var (
+	aclResourceTypes   = []string{"Unknown", "Any", ACLResourceTopic, ACLResourceGroup, "Cluster", "TransactionalID"}
+	aclPatternTypes    = []string{"Unknown", "Any", "Match", "Literal", "Prefixed"}
+	aclOperations      = []string{"Unknown", "Any", "All", "Read", "Write", "Create", "Delete", "Alter", "Describe", "ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite"}
+	aclPermissionTypes = []string{"Unknown", "Any", "Deny", "Allow"}
+)
+
+var incrementalOperations = map[ConfigOperation]sarama.IncrementalAlterConfigsOperation{
+	ConfigSet:      sarama.IncrementalAlterConfigsOperationSet,
+	ConfigDelete:   sarama.IncrementalAlterConfigsOperationDelete,
+	ConfigAppend:   sarama.IncrementalAlterConfigsOperationAppend,
+	ConfigSubtract: sarama.IncrementalAlterConfigsOperationSubtract,
+}
+
+func aclName(names []string, value int) string {
+	if value < 0 || value >= len(names) {
+		return names[0]
+	}
+	return names[value]
+}
+
+func aclValue(names []string, name string) int {
+	for value, n := range names {
+		if n == name {
+			return value
+		}
+	}
+	return 0
 }
@@ -1,8 +1,13 @@
 package client
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/stretchr/testify/assert"
@@ -200,6 +205,30 @@ func TestSaramaClient_DeleteTopicSuccess(t *testing.T) {
 	admin.AssertExpectations(t)
 }
 
+func TestSaramaClient_DeleteTopicAttemptsAllTopicsAndReturnsTheErrors(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DeleteTopic", "topic-1").Return(errors.New("not authorized"))
+	admin.On("DeleteTopic", "topic-2").Return(nil)
+	admin.On("DeleteTopic", "topic-3").Return(errors.New("unknown topic"))
+
+	err := client.DeleteTopic([]string{"topic-1", "topic-2", "topic-3"})
+
+	assert.EqualError(t, err, "err while deleting 2 of 3 topics: topic-1 - not authorized, topic-3 - unknown topic")
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DeleteRecords(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DeleteRecords", "topic-1", map[int32]int64{0: 10, 1: 20}).Return(nil)
+
+	err := client.DeleteRecords("topic-1", map[int32]int64{0: 10, 1: 20})
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
 func TestSaramaClient_ListBrokersSuccess(t *testing.T) {
 	saramaClient := &MockSaramaClient{}
 	client := SaramaClient{client: saramaClient}
@@ -300,3 +329,592 @@ func TestSaramaClient_GetConsumerGroupsForTopic(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+// memberAssignment encodes the assignment of the first partition of the topics to a member, as sent by the brokers
+func memberAssignment(topics ...string) []byte {
+	var buf bytes.Buffer
+	write := func(v interface{}) { _ = binary.Write(&buf, binary.BigEndian, v) }
+	write(int16(0))
+	write(int32(len(topics)))
+	for _, topic := range topics {
+		write(int16(len(topic)))
+		buf.WriteString(topic)
+		write([]int32{1, 0})
+	}
+	write(int32(-1))
+	return buf.Bytes()
+}
+
+func TestSaramaClient_GetConsumerGroupsForTopicsDescribesGroupsOnce(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DescribeConsumerGroups", []string{"group-1"}).Return([]*sarama.GroupDescription{{GroupId: "group-1",
+		Members: map[string]*sarama.GroupMemberDescription{"member-1": {MemberAssignment: memberAssignment("topic-1", "topic-2")}}}}, nil).Once()
+	admin.On("DescribeConsumerGroups", []string{"group-2"}).Return([]*sarama.GroupDescription{{GroupId: "group-2",
+		Members: map[string]*sarama.GroupMemberDescription{"member-1": {MemberAssignment: memberAssignment("topic-2")}}}}, nil).Once()
+
+	subscribed, err := client.GetConsumerGroupsForTopics([]string{"group-1", "group-2"}, []string{"topic-1", "topic-2", "topic-3"})
+
+	require.NoError(t, err)
+	sort.Strings(subscribed["topic-2"])
+	assert.Equal(t, map[string][]string{"topic-1": {"group-1"}, "topic-2": {"group-1", "group-2"}}, subscribed)
+	admin.AssertExpectations(t)
+}
+
+func TestConsumerGroups_HasSubscriptionChecksEveryMember(t *testing.T) {
+	var members consumerGroups = map[string]*sarama.GroupMemberDescription{
+		"member-1": {MemberAssignment: memberAssignment("topic-1")},
+		"member-2": {MemberAssignment: memberAssignment("topic-2")},
+		"member-3": {MemberAssignment: []byte{0x04, 0x05, 0x06}},
+	}
+
+	assert.True(t, members.HasSubscription("topic-1"))
+	assert.True(t, members.HasSubscription("topic-2"))
+	assert.False(t, members.HasSubscription("topic-3"))
+}
+
+func TestSaramaClient_GetConsumerGroupsForTopicReturnsDescribeErrors(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DescribeConsumerGroups", []string{"group-1"}).Return([]*sarama.GroupDescription{{GroupId: "group-1"}}, nil)
+	admin.On("DescribeConsumerGroups", []string{"group-2"}).Return([]*sarama.GroupDescription{}, errors.New("coordinator not available"))
+
+	_, err := client.GetConsumerGroupsForTopic([]string{"group-1", "group-2"}, "test-topic")
+
+	assert.EqualError(t, err, "err while describing consumer groups: group-2 - coordinator not available")
+}
+
+func TestSaramaClient_ListACLsSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	filter := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+	}
+	resourceACLs := []sarama.ResourceAcls{{
+		Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "topic1", ResourcePatternType: sarama.AclPatternLiteral},
+		Acls:     []*sarama.Acl{{Principal: "User:alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}},
+	}}
+	admin.On("ListAcls", filter).Return(resourceACLs, nil)
+
+	acls, err := client.ListACLs()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ACL{{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:alice",
+		Host: "*", Operation: "Read", PermissionType: "Allow"}}, acls)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_CreateACLSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	resource := sarama.Resource{ResourceType: sarama.AclResourceGroup, ResourceName: "group1", ResourcePatternType: sarama.AclPatternPrefixed}
+	acl := sarama.Acl{Principal: "User:alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}
+	admin.On("CreateACL", resource, acl).Return(nil)
+
+	err := client.CreateACL(ACL{ResourceType: "Group", ResourceName: "group1", PatternType: "Prefixed", Principal: "User:alice",
+		Host: "*", Operation: "Read", PermissionType: "Allow"})
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DeleteACLFailsOnMatchingACLError(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DeleteACL", mock.Anything, false).Return([]sarama.MatchingAcl{{Err: sarama.ErrClusterAuthorizationFailed}}, nil)
+
+	err := client.DeleteACL(ACL{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal"})
+
+	assert.Equal(t, sarama.ErrClusterAuthorizationFailed, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_GetConsumerGroupOffsetsSkipsUncommittedPartitions(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	response := &sarama.OffsetFetchResponse{Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{
+		"topic1": {0: {Offset: 10}, 1: {Offset: -1}},
+	}}
+	admin.On("ListConsumerGroupOffsets", "group1", map[string][]int32(nil)).Return(response, nil)
+
+	offsets, err := client.GetConsumerGroupOffsets("group1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[int32]int64{"topic1": {0: 10}}, offsets)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_GetConsumerGroupOffsetsFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	response := &sarama.OffsetFetchResponse{Err: sarama.ErrNotCoordinatorForConsumer}
+	admin.On("ListConsumerGroupOffsets", "group1", map[string][]int32(nil)).Return(response, nil)
+
+	_, err := client.GetConsumerGroupOffsets("group1")
+
+	assert.Equal(t, sarama.ErrNotCoordinatorForConsumer, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_IncrementalUpdateConfigSendsTheOperations(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{admin: admin, client: saramaClient}
+	mockBroker := newIncrementalAlterConfigsMockBroker(t, sarama.NewMockIncrementalAlterConfigsResponse(t))
+	defer mockBroker.Close()
+	saramaClient.On("Controller").Return(sarama.NewBroker(mockBroker.Addr()), nil)
+	saramaClient.On("Config").Return(newTestConfig())
+	retention := "5000"
+	compact := "compact"
+
+	err := client.IncrementalUpdateConfig(client.GetTopicResourceType(), "topic1", map[string]IncrementalConfigEntry{
+		"retention.ms":   {Operation: ConfigSet, Value: &retention},
+		"segment.ms":     {Operation: ConfigDelete},
+		"cleanup.policy": {Operation: ConfigAppend, Value: &compact},
+	}, true)
+
+	assert.NoError(t, err)
+	history := mockBroker.History()
+	alterRequest := history[len(history)-1].Request.(*sarama.IncrementalAlterConfigsRequest)
+	assert.True(t, alterRequest.ValidateOnly)
+	assert.Equal(t, []*sarama.IncrementalAlterConfigsResource{{Type: sarama.TopicResource, Name: "topic1", ConfigEntries: map[string]sarama.IncrementalAlterConfigsEntry{
+		"retention.ms":   {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &retention},
+		"segment.ms":     {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+		"cleanup.policy": {Operation: sarama.IncrementalAlterConfigsOperationAppend, Value: &compact},
+	}}}, alterRequest.Resources)
+	for _, requestResponse := range history {
+		_, isDescribe := requestResponse.Request.(*sarama.DescribeConfigsRequest)
+		assert.False(t, isDescribe)
+	}
+	admin.AssertNotCalled(t, "AlterConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaramaClient_IncrementalUpdateConfigReturnsTheErrorOfTheResource(t *testing.T) {
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+	mockBroker := newIncrementalAlterConfigsMockBroker(t, sarama.NewMockIncrementalAlterConfigsResponseWithErrorCode(t))
+	defer mockBroker.Close()
+	saramaClient.On("Controller").Return(sarama.NewBroker(mockBroker.Addr()), nil)
+	saramaClient.On("Config").Return(newTestConfig())
+	retention := "5000"
+
+	err := client.IncrementalUpdateConfig(client.GetTopicResourceType(), "topic1", map[string]IncrementalConfigEntry{
+		"retention.ms": {Operation: ConfigSet, Value: &retention},
+	}, false)
+
+	assert.Error(t, err)
+}
+
+func TestSaramaClient_IncrementalUpdateConfigOnOldBrokersRetainsOtherOverrides(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{admin: admin, client: saramaClient}
+	mockBroker, broker := newConfigMockBroker(t, 1, sarama.TopicResource, "topic1", []*sarama.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: sarama.SourceTopic},
+		{Name: "segment.ms", Value: "2000", Source: sarama.SourceTopic},
+		{Name: "segment.bytes", Value: "100", Source: sarama.SourceStaticBroker},
+		{Name: "cleanup.policy", Value: "delete", Source: sarama.SourceDefault},
+	})
+	defer mockBroker.Close()
+	saramaClient.On("Controller").Return(broker, nil)
+	saramaClient.On("Config").Return(newTestConfig())
+	retention := "5000"
+	admin.On("AlterConfig", sarama.TopicResource, "topic1", map[string]*string{"retention.ms": &retention}, false).Return(nil)
+
+	err := client.IncrementalUpdateConfig(client.GetTopicResourceType(), "topic1", map[string]IncrementalConfigEntry{
+		"retention.ms": {Operation: ConfigSet, Value: &retention},
+		"segment.ms":   {Operation: ConfigDelete},
+	}, false)
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_IncrementalUpdateConfigOnOldBrokersAppendsAndSubtractsListValues(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{admin: admin, client: saramaClient}
+	mockBroker, broker := newConfigMockBroker(t, 1, sarama.TopicResource, "topic1", []*sarama.ConfigEntry{
+		{Name: "cleanup.policy", Value: "delete", Source: sarama.SourceDefault},
+		{Name: "leader.replication.throttled.replicas", Value: "0:1,1:2,2:3", Source: sarama.SourceTopic},
+	})
+	defer mockBroker.Close()
+	saramaClient.On("Controller").Return(broker, nil)
+	saramaClient.On("Config").Return(newTestConfig())
+	cleanupPolicy := "delete,compact"
+	throttledReplicas := "0:1,2:3"
+	admin.On("AlterConfig", sarama.TopicResource, "topic1", map[string]*string{
+		"cleanup.policy":                        &cleanupPolicy,
+		"leader.replication.throttled.replicas": &throttledReplicas,
+	}, false).Return(nil)
+
+	compact := "compact,delete"
+	replica := "1:2"
+	err := client.IncrementalUpdateConfig(client.GetTopicResourceType(), "topic1", map[string]IncrementalConfigEntry{
+		"cleanup.policy":                        {Operation: ConfigAppend, Value: &compact},
+		"leader.replication.throttled.replicas": {Operation: ConfigSubtract, Value: &replica},
+	}, false)
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_IncrementalUpdateConfigOnOldBrokersFailsToDropSensitiveOverride(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{admin: admin, client: saramaClient}
+	mockBroker, broker := newConfigMockBroker(t, 1, sarama.TopicResource, "topic1", []*sarama.ConfigEntry{
+		{Name: "secret", Sensitive: true, Source: sarama.SourceTopic},
+	})
+	defer mockBroker.Close()
+	saramaClient.On("Controller").Return(broker, nil)
+	saramaClient.On("Config").Return(newTestConfig())
+	retention := "5000"
+
+	err := client.IncrementalUpdateConfig(client.GetTopicResourceType(), "topic1", map[string]IncrementalConfigEntry{
+		"retention.ms": {Operation: ConfigSet, Value: &retention},
+	}, false)
+
+	assert.Error(t, err)
+	admin.AssertNotCalled(t, "AlterConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaramaClient_IncrementalUpdateBrokerConfigIsSentToTheBroker(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	mockBroker := sarama.NewMockBroker(t, 2)
+	defer mockBroker.Close()
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+			SetController(mockBroker.BrokerID()),
+		"DescribeConfigsRequest": sarama.NewMockWrapper(&sarama.DescribeConfigsResponse{
+			Version: 1,
+			Resources: []*sarama.ResourceResponse{{Type: sarama.BrokerResource, Name: "2", Configs: []*sarama.ConfigEntry{
+				{Name: "log.cleaner.threads", Value: "2", Source: sarama.SourceDynamicBroker},
+				{Name: "num.io.threads", Value: "8", Source: sarama.SourceStaticBroker},
+				{Name: "log.retention.hours", Value: "72", Source: sarama.SourceDynamicDefaultBroker},
+			}}},
+		}),
+		"AlterConfigsRequest": sarama.NewMockWrapper(&sarama.AlterConfigsResponse{
+			Resources: []*sarama.AlterConfigsResourceResponse{{Type: sarama.BrokerResource, Name: "2"}},
+		}),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{admin: admin, client: saramaClient}
+	throttle := "1000"
+
+	err = client.IncrementalUpdateConfig(client.GetBrokerResourceType(), "2", map[string]IncrementalConfigEntry{
+		"leader.replication.throttled.rate": {Operation: ConfigSet, Value: &throttle},
+	}, false)
+
+	assert.NoError(t, err)
+	history := mockBroker.History()
+	alterRequest := history[len(history)-1].Request.(*sarama.AlterConfigsRequest)
+	assert.Equal(t, "2", alterRequest.Resources[0].Name)
+	assert.Len(t, alterRequest.Resources[0].ConfigEntries, 2)
+	assert.Equal(t, "2", *alterRequest.Resources[0].ConfigEntries["log.cleaner.threads"])
+	assert.Equal(t, "1000", *alterRequest.Resources[0].ConfigEntries["leader.replication.throttled.rate"])
+	admin.AssertNotCalled(t, "AlterConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaramaClient_GetBrokerConfigWhenBrokerIsNotFound(t *testing.T) {
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+	saramaClient.On("Brokers").Return([]*sarama.Broker{})
+
+	_, err := client.GetConfig(ConfigResource{Type: client.GetBrokerResourceType(), Name: "3"})
+
+	assert.EqualError(t, err, "broker 3 not found in the cluster")
+}
+
+func TestSaramaClient_GetConfigsBatchesAcrossBrokers(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+
+	var resources []ConfigResource
+	response := &sarama.DescribeConfigsResponse{Version: 1}
+	for i := 0; i < 250; i++ {
+		name := fmt.Sprintf("topic%d", i)
+		resources = append(resources, ConfigResource{Type: int(sarama.TopicResource), Name: name})
+		response.Resources = append(response.Resources, &sarama.ResourceResponse{
+			Name: name,
+			Configs: []*sarama.ConfigEntry{
+				{Name: "retention.ms", Value: "5000", Source: sarama.SourceTopic},
+				{Name: "segment.bytes", Value: "1000", Source: sarama.SourceDefault},
+			},
+		})
+	}
+
+	var mockBrokers []*sarama.MockBroker
+	var brokers []*sarama.Broker
+	for i := int32(1); i <= 2; i++ {
+		mockBroker := sarama.NewMockBroker(t, i)
+		defer mockBroker.Close()
+		mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"DescribeConfigsRequest": sarama.NewMockWrapper(response),
+		})
+		mockBrokers = append(mockBrokers, mockBroker)
+		brokers = append(brokers, sarama.NewBroker(mockBroker.Addr()))
+	}
+	saramaClient.On("Brokers").Return(brokers)
+	saramaClient.On("Config").Return(config)
+
+	configs, err := client.GetConfigs(resources)
+	require.NoError(t, err)
+	assert.Len(t, configs, 250)
+	assert.Equal(t, []ConfigEntry{
+		{Name: "retention.ms", Value: "5000", Source: "Topic"},
+		{Name: "segment.bytes", Value: "1000", Default: true, Source: "Default"},
+	}, configs["topic249"])
+
+	var requests int
+	for _, mockBroker := range mockBrokers {
+		for _, rr := range mockBroker.History() {
+			request := rr.Request.(*sarama.DescribeConfigsRequest)
+			assert.True(t, len(request.Resources) <= describeConfigsBatchSize)
+			requests++
+		}
+	}
+	assert.Equal(t, 3, requests)
+}
+
+func TestSaramaClient_GetConfigsFailure(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+	mockBroker := sarama.NewMockBroker(t, 1)
+	defer mockBroker.Close()
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockWrapper(&sarama.DescribeConfigsResponse{
+			Version:   1,
+			Resources: []*sarama.ResourceResponse{{Name: "topic1", ErrorCode: 3, ErrorMsg: "unknown topic"}},
+		}),
+	})
+	saramaClient.On("Brokers").Return([]*sarama.Broker{sarama.NewBroker(mockBroker.Addr())})
+	saramaClient.On("Config").Return(config)
+
+	_, err := client.GetConfigs([]ConfigResource{{Type: int(sarama.TopicResource), Name: "topic1"}})
+	assert.EqualError(t, err, "err while describing config for topic1 - unknown topic")
+}
+
+func newConsumeMockBroker(t *testing.T) *sarama.MockBroker {
+	mockBroker := sarama.NewMockBroker(t, 1)
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+			SetLeader("topic-1", 0, mockBroker.BrokerID()).
+			SetLeader("topic-1", 1, mockBroker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetVersion(1).
+			SetOffset("topic-1", 0, sarama.OffsetOldest, 0).
+			SetOffset("topic-1", 0, sarama.OffsetNewest, 3).
+			SetOffset("topic-1", 1, sarama.OffsetOldest, 0).
+			SetOffset("topic-1", 1, sarama.OffsetNewest, 1),
+		"FetchRequest": sarama.NewMockFetchResponse(t, 1).
+			SetVersion(7).
+			SetMessage("topic-1", 0, 0, sarama.StringEncoder("value-0")).
+			SetMessage("topic-1", 0, 1, sarama.StringEncoder("value-1")).
+			SetMessage("topic-1", 0, 2, sarama.StringEncoder("value-2")).
+			SetMessage("topic-1", 1, 0, sarama.StringEncoder("value-3")).
+			SetHighWaterMark("topic-1", 0, 3).
+			SetHighWaterMark("topic-1", 1, 1),
+	})
+	return mockBroker
+}
+
+func TestSaramaClient_ConsumeUntilEndOffsets(t *testing.T) {
+	mockBroker := newConsumeMockBroker(t)
+	defer mockBroker.Close()
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{client: saramaClient}
+
+	var values []string
+	err = client.Consume("topic-1", map[int32]int64{0: 1, 1: 0}, map[int32]int64{0: 3, 1: 1}, nil, func(record Record) bool {
+		values = append(values, string(record.Value))
+		return true
+	})
+
+	require.NoError(t, err)
+	sort.Strings(values)
+	assert.Equal(t, []string{"value-1", "value-2", "value-3"}, values)
+}
+
+func TestSaramaClient_ConsumeStopsWhenHandled(t *testing.T) {
+	mockBroker := newConsumeMockBroker(t)
+	defer mockBroker.Close()
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{client: saramaClient}
+
+	consumed := 0
+	err = client.Consume("topic-1", map[int32]int64{0: 0}, nil, nil, func(record Record) bool {
+		consumed++
+		return consumed < 2
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, consumed)
+}
+
+func TestSaramaClient_ConsumeEndsPartitionOnRecordPastEndOffset(t *testing.T) {
+	mockBroker := sarama.NewMockBroker(t, 1)
+	defer mockBroker.Close()
+	// the offsets 2 and 3 are transaction markers, which are never fetched
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+			SetLeader("topic-1", 0, mockBroker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetVersion(1).
+			SetOffset("topic-1", 0, sarama.OffsetOldest, 0).
+			SetOffset("topic-1", 0, sarama.OffsetNewest, 5),
+		"FetchRequest": sarama.NewMockFetchResponse(t, 1).
+			SetVersion(7).
+			SetMessage("topic-1", 0, 0, sarama.StringEncoder("value-0")).
+			SetMessage("topic-1", 0, 1, sarama.StringEncoder("value-1")).
+			SetMessage("topic-1", 0, 4, sarama.StringEncoder("value-4")).
+			SetHighWaterMark("topic-1", 0, 5),
+	})
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{client: saramaClient}
+
+	var values []string
+	start := time.Now()
+	err = client.Consume("topic-1", map[int32]int64{0: 0}, map[int32]int64{0: 3}, nil, func(record Record) bool {
+		values = append(values, string(record.Value))
+		return true
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"value-0", "value-1"}, values)
+	assert.True(t, time.Since(start) < recordFetchTimeout)
+}
+
+func TestSaramaClient_ConsumeReturnsErrorWhenPartitionsAreNotReadToTheEnd(t *testing.T) {
+	mockBroker := newConsumeMockBroker(t)
+	defer mockBroker.Close()
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{client: saramaClient}
+
+	consumed := 0
+	err = client.Consume("topic-1", map[int32]int64{0: 0, 1: 0}, map[int32]int64{0: 5, 1: 1}, nil, func(record Record) bool {
+		consumed++
+		return true
+	})
+
+	assert.EqualError(t, err, "err while consuming topic-1 - no records fetched within 10s, partitions [0] were not read up to their end offsets")
+	assert.Equal(t, 4, consumed)
+}
+
+func newTestConfig() *sarama.Config {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	return config
+}
+
+func newConfigMockBroker(t *testing.T, brokerID int32, resourceType sarama.ConfigResourceType, name string, entries []*sarama.ConfigEntry) (*sarama.MockBroker, *sarama.Broker) {
+	mockBroker := sarama.NewMockBroker(t, brokerID)
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockWrapper(&sarama.DescribeConfigsResponse{
+			Version:   1,
+			Resources: []*sarama.ResourceResponse{{Type: resourceType, Name: name, Configs: entries}},
+		}),
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+	})
+	return mockBroker, sarama.NewBroker(mockBroker.Addr())
+}
+
+func newIncrementalAlterConfigsMockBroker(t *testing.T, response sarama.MockResponse) *sarama.MockBroker {
+	mockBroker := sarama.NewMockBroker(t, 1)
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t).SetApiKeys([]sarama.ApiVersionsResponseKey{
+			{ApiKey: 32, MinVersion: 0, MaxVersion: 2},
+			{ApiKey: 44, MinVersion: 0, MaxVersion: 1},
+		}),
+		"IncrementalAlterConfigsRequest": response,
+	})
+	return mockBroker
+}
+
+func TestSaramaClient_DescribeLogDirsWhenBrokerIsNotFound(t *testing.T) {
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+	saramaClient.On("Brokers").Return([]*sarama.Broker{})
+
+	_, err := client.DescribeLogDirs([]int32{5})
+
+	assert.EqualError(t, err, "broker 5 not found in the cluster")
+}
+
+func TestToLogDirs(t *testing.T) {
+	response := &sarama.DescribeLogDirsResponse{
+		LogDirs: []sarama.DescribeLogDirsResponseDirMetadata{
+			{Path: "/data/1", Topics: []sarama.DescribeLogDirsResponseTopic{{Topic: "topic1", Partitions: []sarama.DescribeLogDirsResponsePartition{
+				{PartitionID: 0, Size: 100, OffsetLag: 2},
+				{PartitionID: 1, Size: 200, IsTemporary: true},
+			}}}},
+			{Path: "/data/2", ErrorCode: sarama.ErrKafkaStorageError},
+		},
+	}
+
+	assert.Equal(t, []LogDir{
+		{Broker: 1, Path: "/data/1", Replicas: []ReplicaLog{
+			{Topic: "topic1", Partition: 0, Size: 100, OffsetLag: 2},
+			{Topic: "topic1", Partition: 1, Size: 200, IsTemporary: true},
+		}},
+		{Broker: 1, Path: "/data/2", Err: sarama.ErrKafkaStorageError},
+	}, toLogDirs(1, response))
+}
+
+func TestSaramaClient_GetRecord(t *testing.T) {
+	mockBroker := sarama.NewMockBroker(t, 1)
+	defer mockBroker.Close()
+	mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+			SetLeader("topic-1", 0, mockBroker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(t).
+			SetVersion(1).
+			SetOffset("topic-1", 0, sarama.OffsetOldest, 0).
+			SetOffset("topic-1", 0, sarama.OffsetNewest, 10),
+		"FetchRequest": sarama.NewMockFetchResponse(t, 1).
+			SetVersion(7).
+			SetMessage("topic-1", 0, 5, sarama.StringEncoder("value")).
+			SetHighWaterMark("topic-1", 0, 10),
+	})
+	saramaClient, err := sarama.NewClient([]string{mockBroker.Addr()}, newTestConfig())
+	require.NoError(t, err)
+	defer saramaClient.Close()
+	client := SaramaClient{client: saramaClient}
+
+	record, err := client.GetRecord("topic-1", 0, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, "topic-1", record.Topic)
+	assert.Equal(t, int64(5), record.Offset)
+	assert.Equal(t, []byte("value"), record.Value)
+}
+
+func TestSaramaClient_ListBrokerRacks(t *testing.T) {
+	saramaClient := &MockSaramaClient{}
+	client := SaramaClient{client: saramaClient}
+	saramaClient.On("Brokers").Return([]*sarama.Broker{sarama.NewBroker("abc:123")})
+
+	assert.Equal(t, map[int32]string{-1: ""}, client.ListBrokerRacks())
+}
@@ -5,43 +5,88 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"os"
+	"strings"
+	"sync"
 
+	"github.com/gojek/kat/logger"
+	"github.com/kevinburke/ssh_config"
+	"github.com/mitchellh/go-homedir"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
+// SSHConfig holds the defaults to ssh into the brokers. The settings of a host in ~/.ssh/config, ie. HostName, User,
+// Port, IdentityFile and ProxyJump, take precedence over them.
+type SSHConfig struct {
+	User                  string
+	Port                  string
+	KeyFile               string
+	JumpHost              string
+	KnownHostsFile        string
+	InsecureIgnoreHostKey bool
+	Passphrase            func(keyFile string) ([]byte, error)
+}
+
+type sshSettings interface {
+	Get(alias, key string) string
+}
+
+type sshHost struct {
+	hostName      string
+	port          string
+	user          string
+	identityFiles []string
+}
+
+type parsedKey struct {
+	signer ssh.Signer
+	err    error
+}
+
 type SSHClient struct {
-	config *ssh.ClientConfig
-	port   string
+	config          SSHConfig
+	settings        sshSettings
+	hostKeyCallback ssh.HostKeyCallback
+	agent           agent.Agent
+	lock            sync.Mutex
+	keys            map[string]parsedKey
 }
 
-func NewSSHClient(user, port, keyfile string) (*SSHClient, error) {
-	key, err := ioutil.ReadFile(keyfile)
-	if err != nil {
-		return nil, err
-	}
+func NewSSHClient(config SSHConfig) (*SSHClient, error) {
+	return newSSHClient(config, ssh_config.DefaultUserSettings)
+}
 
-	signer, err := ssh.ParsePrivateKey(key)
-	if err != nil {
-		return nil, err
+func newSSHClient(config SSHConfig, settings sshSettings) (*SSHClient, error) {
+	s := &SSHClient{config: config, settings: settings, keys: make(map[string]parsedKey)}
+	if config.InsecureIgnoreHostKey {
+		s.hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		callback, err := knownhosts.New(config.KnownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("err while reading known hosts, pass --ssh-insecure-ignore-host-key to skip verifying the host keys - %v", err)
+		}
+		s.hostKeyCallback = callback
 	}
 
-	config := &ssh.ClientConfig{
-		User: user,
-		Auth: []ssh.AuthMethod{
-			ssh.PublicKeys(signer),
-		},
-		HostKeyCallback: ssh.HostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error { return nil }),
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			logger.Warnf("Err while connecting to ssh-agent - %v\n", err)
+		} else {
+			s.agent = agent.NewClient(conn)
+		}
 	}
-
-	return &SSHClient{config: config, port: port}, nil
+	return s, nil
 }
 
 func (s *SSHClient) DialAndExecute(address string, commands ...shellCmd) (*bytes.Buffer, error) {
-	conn, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", address, s.port), s.config)
+	conn, closeConn, err := s.dial(address)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
+	defer closeConn()
 
 	var buffer *bytes.Buffer
 	for _, cmd := range commands {
@@ -70,3 +115,216 @@ func (s *SSHClient) execute(session *ssh.Session, cmd shellCmd) (*bytes.Buffer,
 
 	return &out, nil
 }
+
+// dial connects to the host through the jump hosts if any, and returns a func closing all the connections
+func (s *SSHClient) dial(address string) (*ssh.Client, func(), error) {
+	var clients []*ssh.Client
+	closeAll := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			clients[i].Close()
+		}
+	}
+
+	var conn *ssh.Client
+	for _, host := range append(s.jumpHosts(address), s.host(address, s.config.Port)) {
+		var err error
+		conn, err = s.dialHost(conn, host)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		clients = append(clients, conn)
+	}
+	return conn, closeAll, nil
+}
+
+func (s *SSHClient) dialHost(via *ssh.Client, host sshHost) (*ssh.Client, error) {
+	config := &ssh.ClientConfig{
+		User: host.user,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+				return s.signers(host)
+			}),
+		},
+		HostKeyCallback:   s.hostKeyCallback,
+		HostKeyAlgorithms: s.hostKeyAlgorithms(host),
+	}
+	if via == nil {
+		return ssh.Dial("tcp", host.addr(), config)
+	}
+
+	conn, err := via.Dial("tcp", host.addr())
+	if err != nil {
+		return nil, fmt.Errorf("err while connecting to %v through the jump host - %v", host.addr(), err)
+	}
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, host.addr(), config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(clientConn, channels, requests), nil
+}
+
+// hostKeyAlgorithms returns the algorithms of the keys of the host in known_hosts, so that the host offers a key
+// which can be verified, as OpenSSH does. The defaults of the ssh package are used for hosts not in known_hosts.
+func (s *SSHClient) hostKeyAlgorithms(host sshHost) []string {
+	if s.config.InsecureIgnoreHostKey {
+		return nil
+	}
+	// no key matches the placeholder, so the callback fails listing the keys known for the host
+	err := s.hostKeyCallback(host.addr(), &net.TCPAddr{IP: net.IPv4zero}, placeholderKey{})
+	keyErr, ok := err.(*knownhosts.KeyError)
+	if !ok {
+		return nil
+	}
+
+	var algorithms []string
+	for _, known := range keyErr.Want {
+		if known.Key.Type() == ssh.KeyAlgoRSA {
+			algorithms = append(algorithms, ssh.SigAlgoRSASHA2512, ssh.SigAlgoRSASHA2256, ssh.SigAlgoRSA)
+			continue
+		}
+		algorithms = append(algorithms, known.Key.Type())
+	}
+	return algorithms
+}
+
+type placeholderKey struct{}
+
+func (placeholderKey) Type() string {
+	return "placeholder"
+}
+
+func (placeholderKey) Marshal() []byte {
+	return []byte("placeholder")
+}
+
+func (placeholderKey) Verify([]byte, *ssh.Signature) error {
+	return fmt.Errorf("placeholder key can not verify signatures")
+}
+
+// host resolves the settings of the host from the ssh config, falling back to the defaults
+func (s *SSHClient) host(alias, port string) sshHost {
+	host := sshHost{
+		hostName: s.setting(alias, "HostName", alias),
+		port:     s.setting(alias, "Port", port),
+		user:     s.setting(alias, "User", s.config.User),
+	}
+	if identityFile := s.setting(alias, "IdentityFile", ""); identityFile != "" {
+		host.identityFiles = append(host.identityFiles, identityFile)
+	}
+	if s.config.KeyFile != "" {
+		host.identityFiles = append(host.identityFiles, s.config.KeyFile)
+	}
+	return host
+}
+
+// jumpHosts parses the ProxyJump of the host, or the default jump host, of the form [user@]host[:port],...
+func (s *SSHClient) jumpHosts(alias string) []sshHost {
+	proxyJump := s.setting(alias, "ProxyJump", s.config.JumpHost)
+	if proxyJump == "" || proxyJump == "none" {
+		return nil
+	}
+
+	var hosts []sshHost
+	for _, jump := range strings.Split(proxyJump, ",") {
+		jump = strings.TrimSpace(jump)
+		user := ""
+		if i := strings.LastIndex(jump, "@"); i >= 0 {
+			user, jump = jump[:i], jump[i+1:]
+		}
+		jumpAlias, port := jump, ""
+		if h, p, err := net.SplitHostPort(jump); err == nil {
+			jumpAlias, port = h, p
+		}
+
+		host := s.host(jumpAlias, ssh_config.Default("Port"))
+		if user != "" {
+			host.user = user
+		}
+		if port != "" {
+			host.port = port
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// setting returns the value of the key for the host in the ssh config, or the fallback when it is not set
+func (s *SSHClient) setting(alias, key, fallback string) string {
+	value := s.settings.Get(alias, key)
+	if value == "" || value == ssh_config.Default(key) {
+		return fallback
+	}
+	return value
+}
+
+func (s *SSHClient) signers(host sshHost) ([]ssh.Signer, error) {
+	var signers []ssh.Signer
+	if s.agent != nil {
+		agentSigners, err := s.agent.Signers()
+		if err != nil {
+			logger.Warnf("Err while fetching keys from ssh-agent - %v\n", err)
+		}
+		signers = append(signers, agentSigners...)
+	}
+
+	var keyErr error
+	for _, keyFile := range host.identityFiles {
+		signer, err := s.loadKey(keyFile)
+		if err != nil {
+			keyErr = err
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	if len(signers) == 0 && keyErr != nil {
+		return nil, keyErr
+	}
+	return signers, nil
+}
+
+// loadKey parses the key file once, asking for the passphrase of encrypted keys
+func (s *SSHClient) loadKey(keyFile string) (ssh.Signer, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if key, ok := s.keys[keyFile]; ok {
+		return key.signer, key.err
+	}
+	signer, err := s.parseKey(keyFile)
+	if err != nil && !os.IsNotExist(err) {
+		logger.Warnf("Err while reading ssh key %v - %v\n", keyFile, err)
+	}
+	s.keys[keyFile] = parsedKey{signer: signer, err: err}
+	return signer, err
+}
+
+func (s *SSHClient) parseKey(keyFile string) (ssh.Signer, error) {
+	path, err := homedir.Expand(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if _, ok := err.(*ssh.PassphraseMissingError); !ok {
+		return signer, err
+	}
+	if s.config.Passphrase == nil {
+		return nil, fmt.Errorf("%v is encrypted, add it to ssh-agent", keyFile)
+	}
+	passphrase, err := s.config.Passphrase(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKeyWithPassphrase(key, passphrase)
+}
+
+func (h sshHost) addr() string {
+	return net.JoinHostPort(h.hostName, h.port)
+}
@@ -22,29 +22,24 @@ type FindTopicsCmd struct {
 	dataDir                    string
 	findLastWrittenDirectories string
 	removePathPrefix           string
-	removePartitionSuffix      string
-	sortAndCount               string
-	reorder                    string
+	sort                       string
 }
 
+// NewFindTopicsCmd lists the partition directories, eg: topic-0, that are not modified after lastWritten
 func NewFindTopicsCmd(lastWritten int64, dataDir string) *FindTopicsCmd {
 	return &FindTopicsCmd{
 		lastWritten:                lastWritten,
 		dataDir:                    dataDir,
 		findLastWrittenDirectories: "find %s -maxdepth 1 -not -path \"*/\\.*\" -not -newermt \"%s\"",
 		removePathPrefix:           "xargs -I{} echo {} | rev | cut -d / -f1 | rev",
-		removePartitionSuffix:      "xargs -I{} echo {} | rev | cut -d - -f2- | rev",
-		sortAndCount:               "sort | uniq -c",
-		reorder:                    "awk '{ print $2 \" \" $1}'",
+		sort:                       "sort",
 	}
 }
 
 func (f *FindTopicsCmd) Get() string {
 	dateTime := time.Unix(f.lastWritten, 0)
-	return fmt.Sprintf("%s | %s | %s | %s | %s",
+	return fmt.Sprintf("%s | %s | %s",
 		fmt.Sprintf(f.findLastWrittenDirectories, f.dataDir, dateTime.UTC().Format(time.UnixDate)),
 		f.removePathPrefix,
-		f.removePartitionSuffix,
-		f.sortAndCount,
-		f.reorder)
+		f.sort)
 }
@@ -13,7 +13,7 @@ func init() {
 	logger.SetupLogger("info")
 }
 
-func TestFindTopicsCmd_Get_ReturnsFilesOlderThanDate(t *testing.T) {
+func TestFindTopicsCmd_Get_ReturnsPartitionsOlderThanDate(t *testing.T) {
 	e := &Executor{}
 	testDir := "/tmp/kat-test"
 	e.Execute("mkdir", []string{"-p", testDir})
@@ -29,7 +29,7 @@ func TestFindTopicsCmd_Get_ReturnsFilesOlderThanDate(t *testing.T) {
 	resp, err := e.Execute("bash", []string{fmt.Sprintf("%s/find_command.sh", testDir)})
 
 	assert.NoError(t, err)
-	assert.Equal(t, "abc 2\ndef 2\n", resp.String())
+	assert.Equal(t, "abc-1\nabc-2\ndef-1\ndef-2\n", resp.String())
 	e.Execute("rm", []string{"-rf", testDir})
 }
 
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"regexp"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+type ACL struct {
+	apiClient client.KafkaAPIClient
+}
+
+func NewACL(apiClient client.KafkaAPIClient) *ACL {
+	return &ACL{apiClient: apiClient}
+}
+
+// ListACLs returns the acls bound to the topics and consumer groups matching the given regexes.
+// An empty regex selects none of the resources of that type.
+func (a *ACL) ListACLs(topicRegex, groupRegex string) ([]client.ACL, error) {
+	acls, err := a.apiClient.ListACLs()
+	if err != nil {
+		return nil, err
+	}
+
+	var selectedACLs []client.ACL
+	for _, acl := range acls {
+		var regex string
+		switch acl.ResourceType {
+		case client.ACLResourceTopic:
+			regex = topicRegex
+		case client.ACLResourceGroup:
+			regex = groupRegex
+		}
+		if regex == "" {
+			continue
+		}
+
+		matched, err := regexp.MatchString(regex, acl.ResourceName)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			selectedACLs = append(selectedACLs, acl)
+		}
+	}
+	return selectedACLs, nil
+}
+
+// ListAllACLs returns the acls bound to the resources of every type, including the cluster and transactional ids
+func (a *ACL) ListAllACLs() ([]client.ACL, error) {
+	return a.apiClient.ListACLs()
+}
+
+func (a *ACL) CreateACL(acl client.ACL) error {
+	return a.apiClient.CreateACL(acl)
+}
+
+func (a *ACL) DeleteACL(acl client.ACL) error {
+	return a.apiClient.DeleteACL(acl)
+}
+
+// ACLDifference returns the acls present in from but not in other
+func ACLDifference(from, other []client.ACL) []client.ACL {
+	present := make(map[client.ACL]bool)
+	for _, acl := range other {
+		present[acl] = true
+	}
+
+	var difference []client.ACL
+	for _, acl := range from {
+		if !present[acl] {
+			difference = append(difference, acl)
+		}
+	}
+	return difference
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACL_ListACLsFiltersByResource(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	aclCli := NewACL(kafkaClient)
+	topicACL := client.ACL{ResourceType: client.ACLResourceTopic, ResourceName: "topic1"}
+	otherTopicACL := client.ACL{ResourceType: client.ACLResourceTopic, ResourceName: "other"}
+	groupACL := client.ACL{ResourceType: client.ACLResourceGroup, ResourceName: "group1"}
+	clusterACL := client.ACL{ResourceType: "Cluster", ResourceName: "kafka-cluster"}
+	kafkaClient.On("ListACLs").Return([]client.ACL{topicACL, otherTopicACL, groupACL, clusterACL}, nil)
+
+	acls, err := aclCli.ListACLs("topic.*", "group.*")
+	assert.NoError(t, err)
+	assert.Equal(t, []client.ACL{topicACL, groupACL}, acls)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestACL_ListACLsSkipsResourceTypeWithEmptyRegex(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	aclCli := NewACL(kafkaClient)
+	topicACL := client.ACL{ResourceType: client.ACLResourceTopic, ResourceName: "topic1"}
+	groupACL := client.ACL{ResourceType: client.ACLResourceGroup, ResourceName: "group1"}
+	kafkaClient.On("ListACLs").Return([]client.ACL{topicACL, groupACL}, nil)
+
+	acls, err := aclCli.ListACLs("", ".*")
+	assert.NoError(t, err)
+	assert.Equal(t, []client.ACL{groupACL}, acls)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestACL_ListACLsFailure(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	aclCli := NewACL(kafkaClient)
+	expectedErr := errors.New("error")
+	kafkaClient.On("ListACLs").Return([]client.ACL{}, expectedErr)
+
+	_, err := aclCli.ListACLs(".*", ".*")
+	assert.Equal(t, expectedErr, err)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestACLDifference(t *testing.T) {
+	topicACL := client.ACL{ResourceType: client.ACLResourceTopic, ResourceName: "topic1"}
+	groupACL := client.ACL{ResourceType: client.ACLResourceGroup, ResourceName: "group1"}
+
+	assert.Equal(t, []client.ACL{groupACL}, ACLDifference([]client.ACL{topicACL, groupACL}, []client.ACL{topicACL}))
+	assert.Empty(t, ACLDifference([]client.ACL{topicACL}, []client.ACL{topicACL, groupACL}))
+}
+
+func TestACL_ListAllACLsIncludesEveryResourceType(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	aclCli := NewACL(kafkaClient)
+	allACLs := []client.ACL{
+		{ResourceType: client.ACLResourceTopic, ResourceName: "topic1"},
+		{ResourceType: "Cluster", ResourceName: "kafka-cluster"},
+		{ResourceType: "TransactionalID", ResourceName: "txn-1"},
+	}
+	kafkaClient.On("ListACLs").Return(allACLs, nil)
+
+	acls, err := aclCli.ListAllACLs()
+	assert.NoError(t, err)
+	assert.Equal(t, allACLs, acls)
+	kafkaClient.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+// ClusterDefault is the name of the resource holding the dynamic configs shared by all the brokers
+const ClusterDefault = ""
+
+type Broker struct {
+	apiClient client.KafkaAPIClient
+}
+
+func NewBroker(apiClient client.KafkaAPIClient) *Broker {
+	return &Broker{apiClient: apiClient}
+}
+
+func (b *Broker) ListBrokerIDs() []int {
+	var ids []int
+	for id := range b.apiClient.ListBrokers() {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (b *Broker) ListBrokerRacks() map[int32]string {
+	return b.apiClient.ListBrokerRacks()
+}
+
+func (b *Broker) GetConfigs(brokers []string) (map[string][]client.ConfigEntry, error) {
+	configs := make(map[string][]client.ConfigEntry)
+	for _, broker := range brokers {
+		configResource := client.ConfigResource{Name: broker, Type: b.apiClient.GetBrokerResourceType()}
+		entries, err := b.apiClient.GetConfig(configResource)
+		if err != nil {
+			return nil, err
+		}
+		configs[broker] = entries
+	}
+	return configs, nil
+}
+
+func (b *Broker) IncrementalUpdateConfig(brokers []string, entries map[string]client.IncrementalConfigEntry, validateOnly bool) error {
+	for _, broker := range brokers {
+		err := b.apiClient.IncrementalUpdateConfig(b.apiClient.GetBrokerResourceType(), broker, entries, validateOnly)
+		if err != nil {
+			logger.Errorf("Err while updating config for broker - %v: %v\n", broker, err)
+			return err
+		}
+		if validateOnly {
+			logger.Infof("Configuration was successfully validated for broker - %v\n", broker)
+		} else {
+			logger.Infof("Configuration was successfully updated for broker - %v\n", broker)
+		}
+	}
+	return nil
+}
+
+func (b *Broker) DescribeLogDirs(brokerIDs []int32) ([]client.LogDir, error) {
+	logDirs, err := b.apiClient.DescribeLogDirs(brokerIDs)
+	if err != nil {
+		logger.Errorf("Err while describing log dirs - %v\n", err)
+		return nil, err
+	}
+	return logDirs, nil
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_ListBrokerIDsInOrder(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	brokerCli := NewBroker(kafkaClient)
+	kafkaClient.On("ListBrokers").Return(map[int]string{3: "broker3", 1: "broker1", 2: "broker2"})
+
+	assert.Equal(t, []int{1, 2, 3}, brokerCli.ListBrokerIDs())
+}
+
+func TestBroker_GetConfigsSuccess(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	brokerCli := NewBroker(kafkaClient)
+	kafkaClient.On("GetBrokerResourceType").Return(int(sarama.BrokerResource))
+	brokerConfigs := []client.ConfigEntry{{Name: "log.cleaner.threads", Value: "2"}}
+	defaultConfigs := []client.ConfigEntry{{Name: "log.cleaner.threads", Value: "1"}}
+	kafkaClient.On("GetConfig", client.ConfigResource{Type: int(sarama.BrokerResource), Name: "1"}).Return(brokerConfigs, nil)
+	kafkaClient.On("GetConfig", client.ConfigResource{Type: int(sarama.BrokerResource), Name: ClusterDefault}).Return(defaultConfigs, nil)
+
+	configs, err := brokerCli.GetConfigs([]string{"1", ClusterDefault})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]client.ConfigEntry{"1": brokerConfigs, ClusterDefault: defaultConfigs}, configs)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestBroker_IncrementalUpdateConfigFailure(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	brokerCli := NewBroker(kafkaClient)
+	kafkaClient.On("GetBrokerResourceType").Return(int(sarama.BrokerResource))
+	threads := "2"
+	entries := map[string]client.IncrementalConfigEntry{"log.cleaner.threads": {Operation: client.ConfigSet, Value: &threads}}
+	expectedErr := errors.New("error")
+	kafkaClient.On("IncrementalUpdateConfig", int(sarama.BrokerResource), "1", entries, false).Return(expectedErr)
+
+	err := brokerCli.IncrementalUpdateConfig([]string{"1", "2"}, entries, false)
+
+	assert.Equal(t, expectedErr, err)
+	kafkaClient.AssertNotCalled(t, "IncrementalUpdateConfig", int(sarama.BrokerResource), "2", entries, false)
+}
+
+func TestBroker_DescribeLogDirs(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	brokerCli := NewBroker(kafkaClient)
+	logDirs := []client.LogDir{{Broker: 1, Path: "/data"}}
+	kafkaClient.On("DescribeLogDirs", []int32{1}).Return(logDirs, nil)
+	kafkaClient.On("DescribeLogDirs", []int32{2}).Return([]client.LogDir{}, errors.New("error"))
+
+	actual, err := brokerCli.DescribeLogDirs([]int32{1})
+	assert.NoError(t, err)
+	assert.Equal(t, logDirs, actual)
+
+	_, err = brokerCli.DescribeLogDirs([]int32{2})
+	assert.Error(t, err)
+}
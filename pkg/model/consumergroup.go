@@ -0,0 +1,53 @@
+package model
+
+import (
+	"sort"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+type ConsumerGroup struct {
+	apiClient client.KafkaAPIClient
+}
+
+func NewConsumerGroup(apiClient client.KafkaAPIClient) *ConsumerGroup {
+	return &ConsumerGroup{apiClient: apiClient}
+}
+
+func (c *ConsumerGroup) ListGroups(regex string) ([]string, error) {
+	groupMap, err := c.apiClient.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for group := range groupMap {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return ListUtil{groups}.Filter(regex, true)
+}
+
+// ListGroupsForTopics lists the groups with members subscribed to each of the topics, describing the groups once
+func (c *ConsumerGroup) ListGroupsForTopics(topics []string) (map[string][]string, error) {
+	groups, err := c.ListGroups(".*")
+	if err != nil {
+		return nil, err
+	}
+	subscribed, err := c.apiClient.GetConsumerGroupsForTopics(groups, topics)
+	if err != nil {
+		return nil, err
+	}
+	for _, topicGroups := range subscribed {
+		sort.Strings(topicGroups)
+	}
+	return subscribed, nil
+}
+
+func (c *ConsumerGroup) GetOffsets(group string) (map[string]map[int32]int64, error) {
+	return c.apiClient.GetConsumerGroupOffsets(group)
+}
+
+func (c *ConsumerGroup) CommitOffsets(group string, offsets map[string]map[int32]int64) error {
+	return c.apiClient.CommitConsumerGroupOffsets(group, offsets)
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerGroup_ListGroupsReturnsMatchingGroupsInOrder(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	groupCli := NewConsumerGroup(kafkaClient)
+	kafkaClient.On("ListConsumerGroups").Return(map[string]string{"group-b": "consumer", "group-a": "consumer", "other": "consumer"}, nil)
+
+	groups, err := groupCli.ListGroups("group-.*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"group-a", "group-b"}, groups)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestConsumerGroup_ListGroupsFailure(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	groupCli := NewConsumerGroup(kafkaClient)
+	expectedErr := errors.New("error")
+	kafkaClient.On("ListConsumerGroups").Return(map[string]string{}, expectedErr)
+
+	_, err := groupCli.ListGroups(".*")
+	assert.Equal(t, expectedErr, err)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestConsumerGroup_ListGroupsForTopicsInOrder(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	groupCli := NewConsumerGroup(kafkaClient)
+	kafkaClient.On("ListConsumerGroups").Return(map[string]string{"group-b": "consumer", "group-a": "consumer"}, nil)
+	kafkaClient.On("GetConsumerGroupsForTopics", []string{"group-a", "group-b"}, []string{"topic1", "topic2"}).
+		Return(map[string][]string{"topic1": {"group-b", "group-a"}}, nil)
+
+	groups, err := groupCli.ListGroupsForTopics([]string{"topic1", "topic2"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"topic1": {"group-a", "group-b"}}, groups)
+	kafkaClient.AssertExpectations(t)
+}
@@ -1,6 +1,10 @@
 package model
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
 )
@@ -25,9 +29,9 @@ func NewTopic(apiClient client.KafkaAPIClient, opts ...TopicOpts) (*Topic, error
 
 type TopicOpts func(*Topic) error
 
-func WithSSHClient(user, port, keyfile string) TopicOpts {
+func WithSSHClient(config client.SSHConfig) TopicOpts {
 	return func(t *Topic) error {
-		sshClient, err := client.NewSSHClient(user, port, keyfile)
+		sshClient, err := client.NewSSHClient(config)
 		if err != nil {
 			return err
 		}
@@ -53,13 +57,44 @@ func (t *Topic) List() (map[string]client.TopicDetail, error) {
 	return t.apiClient.ListTopicDetails()
 }
 
-func (t *Topic) ListLastWrittenTopics(lastWrittenEpoch int64, dataDir string) ([]string, error) {
+func (t *Topic) ListLastWrittenTopics(lastWrittenEpoch int64, dataDir string, allowPartial bool) ([]string, error) {
 	return t.sshClient.ListTopics(client.ListTopicsRequest{
-		LastWritten: lastWrittenEpoch,
-		DataDir:     dataDir,
+		LastWritten:  lastWrittenEpoch,
+		DataDir:      dataDir,
+		AllowPartial: allowPartial,
 	})
 }
 
+// ListLastWrittenTopicsByTimestamp lists the topics with no record written after the given epoch in any partition.
+// The brokers look up the offset of the first record after the epoch in the time index, so it needs no ssh access.
+func (t *Topic) ListLastWrittenTopicsByTimestamp(lastWrittenEpoch int64) ([]string, error) {
+	topicDetails, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := lastWrittenEpoch * int64(time.Second/time.Millisecond)
+	var topics []string
+	for topic, detail := range topicDetails {
+		isStale := true
+		for partition := int32(0); partition < detail.NumPartitions; partition++ {
+			offset, err := t.apiClient.GetOffset(topic, partition, timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("err while fetching offset of %v-%v - %v", topic, partition, err)
+			}
+			if offset >= 0 {
+				isStale = false
+				break
+			}
+		}
+		if isStale {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
 func (t *Topic) ListOnly(regex string, include bool) ([]string, error) {
 	topicDetails, err := t.List()
 	if err != nil {
@@ -82,6 +117,14 @@ func (t *Topic) GetConfig(topic string) ([]client.ConfigEntry, error) {
 	return t.apiClient.GetConfig(configResource)
 }
 
+func (t *Topic) GetConfigs(topics []string) (map[string][]client.ConfigEntry, error) {
+	var configResources []client.ConfigResource
+	for _, topic := range topics {
+		configResources = append(configResources, client.ConfigResource{Name: topic, Type: t.apiClient.GetTopicResourceType()})
+	}
+	return t.apiClient.GetConfigs(configResources)
+}
+
 func (t *Topic) UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error {
 	for _, topicName := range topics {
 		err := t.apiClient.UpdateConfig(t.apiClient.GetTopicResourceType(), topicName, configMap, validateOnly)
@@ -94,6 +137,42 @@ func (t *Topic) UpdateConfig(topics []string, configMap map[string]*string, vali
 	return nil
 }
 
+func (t *Topic) IncrementalUpdateConfig(topics []string, entries map[string]client.IncrementalConfigEntry, validateOnly bool) error {
+	for _, topicName := range topics {
+		err := t.apiClient.IncrementalUpdateConfig(t.apiClient.GetTopicResourceType(), topicName, entries, validateOnly)
+		if err != nil {
+			logger.Errorf("Err while updating config for topic - %v: %v\n", topicName, err)
+			return err
+		}
+		if validateOnly {
+			logger.Infof("Configuration was successfully validated for topic - %v\n", topicName)
+		} else {
+			logger.Infof("Configuration was successfully updated for topic - %v\n", topicName)
+		}
+	}
+	return nil
+}
+
+func (t *Topic) GetOffset(topic string, partition int32, timestamp int64) (int64, error) {
+	return t.apiClient.GetOffset(topic, partition, timestamp)
+}
+
+func (t *Topic) GetRecordTimestamp(topic string, partition int32, offset int64) (time.Time, error) {
+	return t.apiClient.GetRecordTimestamp(topic, partition, offset)
+}
+
+func (t *Topic) GetRecord(topic string, partition int32, offset int64) (client.Record, error) {
+	return t.apiClient.GetRecord(topic, partition, offset)
+}
+
+func (t *Topic) Consume(topic string, startOffsets, endOffsets map[int32]int64, stop <-chan struct{}, handle func(client.Record) bool) error {
+	return t.apiClient.Consume(topic, startOffsets, endOffsets, stop, handle)
+}
+
 func (t *Topic) Delete(topics []string) error {
 	return t.apiClient.DeleteTopic(topics)
 }
+
+func (t *Topic) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	return t.apiClient.DeleteRecords(topic, partitionOffsets)
+}
@@ -29,6 +29,38 @@ func TestTopic_ListSuccess(t *testing.T) {
 	kafkaClient.AssertExpectations(t)
 }
 
+func TestTopic_ListLastWrittenTopicsByTimestamp(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, _ := NewTopic(kafkaClient)
+	kafkaClient.On("ListTopicDetails").Return(map[string]client.TopicDetail{
+		"stale":  {NumPartitions: 2},
+		"active": {NumPartitions: 2},
+		"empty":  {NumPartitions: 0},
+	}, nil)
+	kafkaClient.On("GetOffset", "stale", int32(0), int64(1000000)).Return(int64(-1), nil)
+	kafkaClient.On("GetOffset", "stale", int32(1), int64(1000000)).Return(int64(-1), nil)
+	kafkaClient.On("GetOffset", "active", int32(0), int64(1000000)).Return(int64(-1), nil)
+	kafkaClient.On("GetOffset", "active", int32(1), int64(1000000)).Return(int64(10), nil)
+
+	topics, err := topicCli.ListLastWrittenTopicsByTimestamp(1000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"empty", "stale"}, topics)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestTopic_ListLastWrittenTopicsByTimestampFailure(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, _ := NewTopic(kafkaClient)
+	kafkaClient.On("ListTopicDetails").Return(map[string]client.TopicDetail{"topic1": {NumPartitions: 1}}, nil)
+	kafkaClient.On("GetOffset", "topic1", int32(0), int64(1000000)).Return(int64(0), errors.New("error"))
+
+	_, err := topicCli.ListLastWrittenTopicsByTimestamp(1000)
+
+	assert.Error(t, err)
+	kafkaClient.AssertExpectations(t)
+}
+
 func TestTopic_ListFailure(t *testing.T) {
 	kafkaClient := &client.MockKafkaAPIClient{}
 	topicCli, err := NewTopic(kafkaClient)
@@ -160,6 +192,26 @@ func TestTopic_ShowConfigFailure(t *testing.T) {
 	kafkaClient.AssertExpectations(t)
 }
 
+func TestTopic_GetConfigsSuccess(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, _ := NewTopic(kafkaClient)
+	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
+	configResources := []client.ConfigResource{
+		{Type: int(sarama.TopicResource), Name: "topic1"},
+		{Type: int(sarama.TopicResource), Name: "topic2"},
+	}
+	expectedConfigs := map[string][]client.ConfigEntry{
+		"topic1": {{Name: "key1", Value: "val1"}},
+		"topic2": {{Name: "key2", Value: "val2"}},
+	}
+	kafkaClient.On("GetConfigs", configResources).Return(expectedConfigs, nil)
+
+	configs, err := topicCli.GetConfigs([]string{"topic1", "topic2"})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedConfigs, configs)
+	kafkaClient.AssertExpectations(t)
+}
+
 func TestTopic_DeleteSuccess(t *testing.T) {
 	kafkaClient := &client.MockKafkaAPIClient{}
 	topicCli, _ := NewTopic(kafkaClient)
@@ -241,3 +293,18 @@ func TestTopic_CreatePartitionsFailure(t *testing.T) {
 	assert.Error(t, err)
 	kafkaClient.AssertExpectations(t)
 }
+
+func TestTopic_IncrementalUpdateConfigSuccess(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, _ := NewTopic(kafkaClient)
+
+	topics := []string{"topic1", "topic2"}
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": {Operation: client.ConfigDelete}}
+	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
+	kafkaClient.On("IncrementalUpdateConfig", int(sarama.TopicResource), "topic1", entries, false).Return(nil)
+	kafkaClient.On("IncrementalUpdateConfig", int(sarama.TopicResource), "topic2", entries, false).Return(nil)
+
+	err := topicCli.IncrementalUpdateConfig(topics, entries, false)
+	assert.NoError(t, err)
+	kafkaClient.AssertExpectations(t)
+}
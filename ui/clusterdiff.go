@@ -0,0 +1,20 @@
+package ui
+
+type ClusterDiffRow struct {
+	resource   string
+	difference string
+	left       string
+	right      string
+}
+
+func ClusterDiff(resource, difference, left, right string) ClusterDiffRow {
+	return ClusterDiffRow{resource: resource, difference: difference, left: left, right: right}
+}
+
+func (c ClusterDiffRow) FieldValues() []string {
+	return []string{c.resource, c.difference, c.left, c.right}
+}
+
+func (c ClusterDiffRow) Headers() []string {
+	return []string{"Resource", "Difference", "Left", "Right"}
+}
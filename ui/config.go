@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+type ConfigChangeRow struct {
+	resource string
+	config   string
+	before   string
+	after    string
+}
+
+func ConfigChange(resource, config, before, after string) ConfigChangeRow {
+	return ConfigChangeRow{resource: resource, config: config, before: before, after: after}
+}
+
+func (c ConfigChangeRow) FieldValues() []string {
+	return []string{c.resource, c.config, c.before, c.after}
+}
+
+func (c ConfigChangeRow) Headers() []string {
+	return []string{"Resource", "Config", "Before", "After"}
+}
+
+type ConfigRow struct {
+	resource string
+	entry    client.ConfigEntry
+}
+
+func Config(resource string, entry client.ConfigEntry) ConfigRow {
+	return ConfigRow{resource: resource, entry: entry}
+}
+
+func (c ConfigRow) FieldValues() []string {
+	return []string{c.resource, c.entry.Name, c.entry.Value, c.entry.Source, fmt.Sprint(c.entry.Default), fmt.Sprint(c.entry.ReadOnly), fmt.Sprint(c.entry.Sensitive)}
+}
+
+func (c ConfigRow) Headers() []string {
+	return []string{"Resource", "Name", "Value", "Source", "Default", "ReadOnly", "Sensitive"}
+}
+
+type ConfigCompareRow struct {
+	name      string
+	resources []string
+	values    []string
+}
+
+func ConfigCompare(name string, resources, values []string) ConfigCompareRow {
+	return ConfigCompareRow{name: name, resources: resources, values: values}
+}
+
+func (c ConfigCompareRow) FieldValues() []string {
+	return append([]string{c.name}, c.values...)
+}
+
+func (c ConfigCompareRow) Headers() []string {
+	return append([]string{"Config"}, c.resources...)
+}
+
+type PolicyViolationRow struct {
+	topic   string
+	check   string
+	actual  string
+	fixable bool
+}
+
+func PolicyViolation(topic, check, actual string, fixable bool) PolicyViolationRow {
+	return PolicyViolationRow{topic: topic, check: check, actual: actual, fixable: fixable}
+}
+
+func (p PolicyViolationRow) FieldValues() []string {
+	return []string{p.topic, p.check, p.actual, fmt.Sprint(p.fixable)}
+}
+
+func (p PolicyViolationRow) Headers() []string {
+	return []string{"Topic", "Rule", "Actual", "Fixable"}
+}
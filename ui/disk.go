@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+)
+
+var sizeUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+
+type DiskUsageRow struct {
+	headers []string
+	values  []string
+	size    int64
+}
+
+func DiskUsage(headers, values []string, size int64) DiskUsageRow {
+	return DiskUsageRow{headers: headers, values: values, size: size}
+}
+
+func (d DiskUsageRow) FieldValues() []string {
+	return append(append([]string{}, d.values...), HumanizeBytes(d.size), fmt.Sprint(d.size))
+}
+
+func (d DiskUsageRow) Headers() []string {
+	return append(append([]string{}, d.headers...), "Size", "Bytes")
+}
+
+// HumanizeBytes formats the size in binary units, eg: 1536 as 1.5 KiB
+func HumanizeBytes(size int64) string {
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(sizeUnits)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f %s", value, sizeUnits[unit])
+}
@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanizeBytes(t *testing.T) {
+	assert.Equal(t, "512 B", HumanizeBytes(512))
+	assert.Equal(t, "1.5 KiB", HumanizeBytes(1536))
+	assert.Equal(t, "2.0 GiB", HumanizeBytes(2*1024*1024*1024))
+}
+
+func TestDiskUsage(t *testing.T) {
+	row := DiskUsage([]string{"Topic", "Partition"}, []string{"topic1", "0"}, 2048)
+
+	assert.Equal(t, []string{"Topic", "Partition", "Size", "Bytes"}, row.Headers())
+	assert.Equal(t, []string{"topic1", "0", "2.0 KiB", "2048"}, row.FieldValues())
+}
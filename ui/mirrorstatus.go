@@ -1,6 +1,10 @@
 package ui
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/gojek/kat/pkg/client"
+)
 
 type MirrorStatusRow struct {
 	topic             string
@@ -19,18 +23,7 @@ func MirrorStatus(topic, config string, oldPartitionCount, newPartitionCount int
 	} else {
 		actionType = update
 	}
-	var mirrorStatus status
-	var reason string
-	if isDryRun {
-		mirrorStatus = dryRun
-	} else {
-		if err == nil {
-			mirrorStatus = success
-		} else {
-			mirrorStatus = failure
-			reason = err.Error()
-		}
-	}
+	mirrorStatus, reason := statusOf(isDryRun, err)
 
 	return MirrorStatusRow{
 		topic:             topic,
@@ -51,15 +44,101 @@ func (m MirrorStatusRow) Headers() []string {
 	return []string{"topic", "Action", "Configs", "OldPartitionCount", "NewPartitionCount", "Status", "Reason"}
 }
 
+type ACLMirrorStatusRow struct {
+	resource string
+	action   action
+	acl      string
+	status   status
+	reason   string
+}
+
+func ACLMirrorStatus(acl client.ACL, isCreate, isDryRun bool, err error) ACLMirrorStatusRow {
+	actionType := remove
+	if isCreate {
+		actionType = create
+	}
+	mirrorStatus, reason := statusOf(isDryRun, err)
+
+	return ACLMirrorStatusRow{
+		resource: acl.Resource(),
+		action:   actionType,
+		acl:      acl.Binding(),
+		status:   mirrorStatus,
+		reason:   reason,
+	}
+}
+
+func (m ACLMirrorStatusRow) FieldValues() []string {
+	return []string{m.resource, m.action.String(), m.acl, m.status.String(), m.reason}
+}
+
+func (m ACLMirrorStatusRow) Headers() []string {
+	return []string{"Resource", "Action", "ACL", "Status", "Reason"}
+}
+
+type OffsetMirrorStatusRow struct {
+	group        string
+	topic        string
+	partition    int32
+	sourceOffset int64
+	oldOffset    int64
+	newOffset    int64
+	status       status
+	reason       string
+}
+
+func OffsetMirrorStatus(group, topic string, partition int32, sourceOffset, oldOffset, newOffset int64, isDryRun bool, err error) OffsetMirrorStatusRow {
+	mirrorStatus, reason := statusOf(isDryRun, err)
+
+	return OffsetMirrorStatusRow{
+		group:        group,
+		topic:        topic,
+		partition:    partition,
+		sourceOffset: sourceOffset,
+		oldOffset:    oldOffset,
+		newOffset:    newOffset,
+		status:       mirrorStatus,
+		reason:       reason,
+	}
+}
+
+func (m OffsetMirrorStatusRow) FieldValues() []string {
+	return []string{m.group, m.topic, fmt.Sprint(m.partition), offsetString(m.sourceOffset), offsetString(m.oldOffset),
+		offsetString(m.newOffset), m.status.String(), m.reason}
+}
+
+func (m OffsetMirrorStatusRow) Headers() []string {
+	return []string{"Group", "Topic", "Partition", "SourceOffset", "OldOffset", "NewOffset", "Status", "Reason"}
+}
+
+// offsetString renders the offsets that are not committed or could not be translated as "-"
+func offsetString(offset int64) string {
+	if offset < 0 {
+		return "-"
+	}
+	return fmt.Sprint(offset)
+}
+
+func statusOf(isDryRun bool, err error) (status, string) {
+	if isDryRun {
+		return dryRun, ""
+	}
+	if err != nil {
+		return failure, err.Error()
+	}
+	return success, ""
+}
+
 type action int
 
 const (
 	create action = iota
 	update
+	remove
 )
 
 func (s action) String() string {
-	return [...]string{"Create", "Update"}[s]
+	return [...]string{"Create", "Update", "Delete"}[s]
 }
 
 type status int
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/gojek/kat/pkg/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -99,3 +100,29 @@ func TestMirrorStatus_Headers(t *testing.T) {
 
 	assert.Equal(t, []string{"topic", "Action", "Configs", "OldPartitionCount", "NewPartitionCount", "Status", "Reason"}, mirrorStatus.Headers())
 }
+
+func TestACLMirrorStatus_CreateSuccess(t *testing.T) {
+	acl := client.ACL{ResourceType: "Topic", ResourceName: "topic-1", PatternType: "Literal", Principal: "User:alice",
+		Host: "*", Operation: "Read", PermissionType: "Allow"}
+
+	mirrorStatus := ACLMirrorStatus(acl, true, false, nil)
+
+	assert.Equal(t, []string{"Topic:topic-1 (Literal)", "Create", "User:alice Allow Read from *", "Success", ""}, mirrorStatus.FieldValues())
+}
+
+func TestACLMirrorStatus_DeleteFailure(t *testing.T) {
+	acl := client.ACL{ResourceType: "Group", ResourceName: "group-1", PatternType: "Prefixed", Principal: "User:bob",
+		Host: "*", Operation: "Read", PermissionType: "Deny"}
+
+	mirrorStatus := ACLMirrorStatus(acl, false, false, errors.New("error"))
+
+	assert.Equal(t, []string{"Group:group-1 (Prefixed)", "Delete", "User:bob Deny Read from *", "Failure", "error"}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"Resource", "Action", "ACL", "Status", "Reason"}, mirrorStatus.Headers())
+}
+
+func TestOffsetMirrorStatus_UncommittedOffsetDryRun(t *testing.T) {
+	mirrorStatus := OffsetMirrorStatus("group-1", "topic-1", 2, 100, -1, 80, true, nil)
+
+	assert.Equal(t, []string{"group-1", "topic-1", "2", "100", "-", "80", "DryRun", ""}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"Group", "Topic", "Partition", "SourceOffset", "OldOffset", "NewOffset", "Status", "Reason"}, mirrorStatus.Headers())
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+type PartitionAdditionRow struct {
+	topic      string
+	partitions int32
+	count      int32
+	assignment [][]int32
+	keyed      bool
+}
+
+func PartitionAddition(topic string, partitions, count int32, assignment [][]int32, keyed bool) PartitionAdditionRow {
+	return PartitionAdditionRow{topic: topic, partitions: partitions, count: count, assignment: assignment, keyed: keyed}
+}
+
+func (p PartitionAdditionRow) FieldValues() []string {
+	assignment := "by controller"
+	if len(p.assignment) != 0 {
+		var partitions []string
+		for _, replicas := range p.assignment {
+			var ids []string
+			for _, id := range replicas {
+				ids = append(ids, fmt.Sprint(id))
+			}
+			partitions = append(partitions, strings.Join(ids, ","))
+		}
+		assignment = strings.Join(partitions, " | ")
+	}
+	return []string{p.topic, fmt.Sprintf("%d -> %d", p.partitions, p.count), assignment, fmt.Sprint(p.keyed)}
+}
+
+func (p PartitionAdditionRow) Headers() []string {
+	return []string{"Topic", "Partitions", "New Replicas", "Keyed"}
+}
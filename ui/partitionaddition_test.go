@@ -0,0 +1,15 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionAddition(t *testing.T) {
+	row := PartitionAddition("topic1", 2, 4, [][]int32{{1, 2}, {2, 3}}, true)
+	assert.Equal(t, []string{"topic1", "2 -> 4", "1,2 | 2,3", "true"}, row.FieldValues())
+
+	row = PartitionAddition("topic1", 2, 4, nil, false)
+	assert.Equal(t, []string{"topic1", "2 -> 4", "by controller", "false"}, row.FieldValues())
+}
@@ -0,0 +1,24 @@
+package ui
+
+import "fmt"
+
+type PartitionPurgeRow struct {
+	topic        string
+	partition    int32
+	lowWatermark int64
+	newLow       int64
+	highMark     int64
+}
+
+func PartitionPurge(topic string, partition int32, lowWatermark, newLowWatermark, highWatermark int64) PartitionPurgeRow {
+	return PartitionPurgeRow{topic: topic, partition: partition, lowWatermark: lowWatermark, newLow: newLowWatermark, highMark: highWatermark}
+}
+
+func (p PartitionPurgeRow) FieldValues() []string {
+	return []string{p.topic, fmt.Sprint(p.partition), fmt.Sprint(p.lowWatermark), fmt.Sprint(p.newLow), fmt.Sprint(p.highMark),
+		fmt.Sprint(p.newLow - p.lowWatermark)}
+}
+
+func (p PartitionPurgeRow) Headers() []string {
+	return []string{"Topic", "Partition", "Low Watermark", "New Low Watermark", "High Watermark", "Dropped"}
+}
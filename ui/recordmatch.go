@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+type RecordMatchRow struct {
+	partition   int32
+	offset      int64
+	timestamp   time.Time
+	key         string
+	value       string
+	header      string
+	headerValue string
+}
+
+// RecordMatch is a row of a matching record, with a column for the value of the header when a header is searched
+func RecordMatch(partition int32, offset int64, timestamp time.Time, key, value, header, headerValue string) RecordMatchRow {
+	return RecordMatchRow{partition: partition, offset: offset, timestamp: timestamp, key: key, value: value, header: header, headerValue: headerValue}
+}
+
+func (r RecordMatchRow) FieldValues() []string {
+	values := []string{fmt.Sprint(r.partition), fmt.Sprint(r.offset), r.timestamp.Format(time.RFC3339Nano), r.key, r.value}
+	if r.header != "" {
+		values = append(values, r.headerValue)
+	}
+	return values
+}
+
+func (r RecordMatchRow) Headers() []string {
+	headers := []string{"Partition", "Offset", "Timestamp", "Key", "Value"}
+	if r.header != "" {
+		headers = append(headers, "Header "+r.header)
+	}
+	return headers
+}
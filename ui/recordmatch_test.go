@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordMatch(t *testing.T) {
+	row := RecordMatch(1, 42, time.Unix(1500000000, 0).UTC(), "key", "value", "", "")
+
+	assert.Equal(t, []string{"Partition", "Offset", "Timestamp", "Key", "Value"}, row.Headers())
+	assert.Equal(t, []string{"1", "42", "2017-07-14T02:40:00Z", "key", "value"}, row.FieldValues())
+}
+
+func TestRecordMatch_ShowsTheSearchedHeader(t *testing.T) {
+	row := RecordMatch(1, 42, time.Unix(1500000000, 0).UTC(), "key", "value", "request-id", "abc")
+
+	assert.Equal(t, []string{"Partition", "Offset", "Timestamp", "Key", "Value", "Header request-id"}, row.Headers())
+	assert.Equal(t, []string{"1", "42", "2017-07-14T02:40:00Z", "key", "value", "abc"}, row.FieldValues())
+}
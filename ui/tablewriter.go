@@ -23,10 +23,25 @@ func (w *TableWriter) Render() {
 	if len(w.rows) == 0 {
 		return
 	}
+	w.table().Render()
+}
+
+func (w *TableWriter) RenderMarkdown() {
+	if len(w.rows) == 0 {
+		return
+	}
+	table := w.table()
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+func (w *TableWriter) table() *tablewriter.Table {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader(w.rows[0].Headers())
 	for _, row := range w.rows {
 		table.Append(row.FieldValues())
 	}
-	table.Render()
+	return table
 }
@@ -0,0 +1,25 @@
+package ui
+
+import "strings"
+
+type TopicDeletionRow struct {
+	topic    string
+	blockers []string
+	action   string
+}
+
+func TopicDeletion(topic string, blockers []string, action string) TopicDeletionRow {
+	return TopicDeletionRow{topic: topic, blockers: blockers, action: action}
+}
+
+func (t TopicDeletionRow) FieldValues() []string {
+	blockers := strings.Join(t.blockers, "\n")
+	if blockers == "" {
+		blockers = "-"
+	}
+	return []string{t.topic, blockers, t.action}
+}
+
+func (t TopicDeletionRow) Headers() []string {
+	return []string{"Topic", "Blockers", "Action"}
+}
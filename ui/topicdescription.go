@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+)
+
+// PartitionDescriptionRow is a partition of a topic with its replicas, offsets and size, negative offsets or size are unknown
+type PartitionDescriptionRow struct {
+	partition       int32
+	leader          int32
+	replicas        []int32
+	isr             []int32
+	offlineReplicas []int32
+	earliestOffset  int64
+	latestOffset    int64
+	size            int64
+}
+
+func PartitionDescription(partition, leader int32, replicas, isr, offlineReplicas []int32, earliestOffset, latestOffset, size int64) PartitionDescriptionRow {
+	return PartitionDescriptionRow{partition: partition, leader: leader, replicas: replicas, isr: isr, offlineReplicas: offlineReplicas,
+		earliestOffset: earliestOffset, latestOffset: latestOffset, size: size}
+}
+
+func (p PartitionDescriptionRow) FieldValues() []string {
+	size := "-"
+	if p.size >= 0 {
+		size = HumanizeBytes(p.size)
+	}
+	earliestOffset, latestOffset, messages := "-", "-", "-"
+	if p.earliestOffset >= 0 && p.latestOffset >= 0 {
+		earliestOffset, latestOffset, messages = fmt.Sprint(p.earliestOffset), fmt.Sprint(p.latestOffset), fmt.Sprint(p.latestOffset-p.earliestOffset)
+	}
+	isPreferredLeader := len(p.replicas) != 0 && p.replicas[0] == p.leader
+	return []string{fmt.Sprint(p.partition), fmt.Sprint(p.leader), fmt.Sprint(p.replicas), fmt.Sprint(p.isr), fmt.Sprint(p.offlineReplicas),
+		fmt.Sprint(isPreferredLeader), fmt.Sprint(len(p.isr) < len(p.replicas)), earliestOffset, latestOffset, messages, size}
+}
+
+func (p PartitionDescriptionRow) Headers() []string {
+	return []string{"Partition", "Leader", "Replicas", "ISR", "Offline Replicas", "Preferred Leader", "Under Replicated",
+		"Earliest Offset", "Latest Offset", "Messages", "Size"}
+}
@@ -0,0 +1,18 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionDescription(t *testing.T) {
+	row := PartitionDescription(0, 2, []int32{1, 2}, []int32{2}, []int32{1}, 10, 110, 2048)
+	assert.Equal(t, []string{"0", "2", "[1 2]", "[2]", "[1]", "false", "true", "10", "110", "100", "2.0 KiB"}, row.FieldValues())
+
+	row = PartitionDescription(1, 1, []int32{1, 2}, []int32{1, 2}, nil, 0, 0, -1)
+	assert.Equal(t, []string{"1", "1", "[1 2]", "[1 2]", "[]", "true", "false", "0", "0", "0", "-"}, row.FieldValues())
+
+	row = PartitionDescription(2, -1, []int32{1, 2}, []int32{}, []int32{1, 2}, -1, -1, -1)
+	assert.Equal(t, []string{"2", "-1", "[1 2]", "[]", "[1 2]", "false", "true", "-", "-", "-", "-"}, row.FieldValues())
+}
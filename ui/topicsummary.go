@@ -0,0 +1,28 @@
+package ui
+
+import "fmt"
+
+type TopicSummaryRow struct {
+	topic             string
+	partitions        int32
+	replicationFactor int16
+	isInternal        bool
+	underReplicated   int
+	configNames       []string
+	configValues      []string
+}
+
+func TopicSummary(topic string, partitions int32, replicationFactor int16, isInternal bool, underReplicated int, configNames, configValues []string) TopicSummaryRow {
+	return TopicSummaryRow{topic: topic, partitions: partitions, replicationFactor: replicationFactor, isInternal: isInternal,
+		underReplicated: underReplicated, configNames: configNames, configValues: configValues}
+}
+
+func (t TopicSummaryRow) FieldValues() []string {
+	values := []string{t.topic, fmt.Sprint(t.partitions), fmt.Sprint(t.replicationFactor), fmt.Sprint(t.isInternal), fmt.Sprint(t.underReplicated)}
+	return append(values, t.configValues...)
+}
+
+func (t TopicSummaryRow) Headers() []string {
+	headers := []string{"Topic", "Partitions", "Replication Factor", "Internal", "Under Replicated Partitions"}
+	return append(headers, t.configNames...)
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicSummary(t *testing.T) {
+	row := TopicSummary("topic1", 3, 2, false, 1, []string{"cleanup.policy"}, []string{"compact"})
+
+	assert.Equal(t, []string{"Topic", "Partitions", "Replication Factor", "Internal", "Under Replicated Partitions", "cleanup.policy"}, row.Headers())
+	assert.Equal(t, []string{"topic1", "3", "2", "false", "1", "compact"}, row.FieldValues())
+}
@@ -3,22 +3,40 @@ package ui
 import (
 	"bufio"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"strings"
+
+	"github.com/gojek/kat/logger"
+
+	"golang.org/x/crypto/ssh/terminal"
 )
 
-type UserInput struct{}
+type UserInput struct {
+	reader io.Reader
+}
 
+// AskForConfirmation asks the question until the answer is yes or no. It is taken as no when the input is closed,
+// eg: when run without a terminal, so that nothing is changed without an explicit yes.
 func (u UserInput) AskForConfirmation(question string) bool {
-	reader := bufio.NewReader(os.Stdin)
+	input := u.reader
+	if input == nil {
+		input = os.Stdin
+	}
+	reader := bufio.NewReader(input)
 
 	for {
 		fmt.Printf("%s [y/n]: ", question)
 
 		response, err := reader.ReadString('\n')
-		if err != nil {
-			log.Fatal(err)
+		if err != nil && (err != io.EOF || strings.TrimSpace(response) == "") {
+			fmt.Println()
+			if err == io.EOF {
+				logger.Warn("No input to confirm, pass --yes to skip the confirmation")
+			} else {
+				logger.Errorf("Err while reading the confirmation - %v\n", err)
+			}
+			return false
 		}
 
 		response = strings.ToLower(strings.TrimSpace(response))
@@ -30,3 +48,10 @@ func (u UserInput) AskForConfirmation(question string) bool {
 		}
 	}
 }
+
+func (u UserInput) AskForPassword(question string) ([]byte, error) {
+	fmt.Printf("%s: ", question)
+	password, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return password, err
+}
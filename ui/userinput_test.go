@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gojek/kat/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func TestUserInput_AskForConfirmation(t *testing.T) {
+	assert.True(t, UserInput{reader: strings.NewReader("yes\n")}.AskForConfirmation("delete?"))
+	assert.True(t, UserInput{reader: strings.NewReader("maybe\nY")}.AskForConfirmation("delete?"))
+	assert.False(t, UserInput{reader: strings.NewReader("n\n")}.AskForConfirmation("delete?"))
+}
+
+func TestUserInput_AskForConfirmationIsNoWhenInputIsClosed(t *testing.T) {
+	assert.False(t, UserInput{reader: strings.NewReader("")}.AskForConfirmation("delete?"))
+	assert.False(t, UserInput{reader: strings.NewReader("maybe")}.AskForConfirmation("delete?"))
+}